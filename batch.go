@@ -0,0 +1,95 @@
+package preveltekit
+
+// Scheduler decides when store notifications are delivered.
+type Scheduler int
+
+const (
+	// ScheduleSync notifies subscribers inside Set (default).
+	ScheduleSync Scheduler = iota
+	// ScheduleMicrotask coalesces notifications until the current JS task
+	// finishes (queueMicrotask). WASM only; SSR always notifies synchronously.
+	ScheduleMicrotask
+	// ScheduleFrame coalesces notifications until the next animation frame
+	// (requestAnimationFrame). WASM only; SSR always notifies synchronously.
+	ScheduleFrame
+)
+
+var (
+	scheduler      Scheduler
+	batchDepth     int      // nesting level of Batch calls
+	flushing       bool     // flushPending is running
+	flushRequested bool     // a scheduled flush is waiting to run
+	pendingNotify  []func() // queued store/list notifications, in Set order
+)
+
+// SetScheduler selects when store notifications are delivered.
+// With ScheduleMicrotask or ScheduleFrame, Set only records the new value;
+// subscribers run once per store at the next flush, no matter how often it
+// was set. Get always returns the latest value.
+//
+// Example:
+//
+//	p.SetScheduler(p.ScheduleFrame) // at most one DOM update per frame
+func SetScheduler(s Scheduler) {
+	scheduler = s
+	if s == ScheduleSync {
+		flushPending()
+	}
+}
+
+// Batch runs fn and delays all store notifications until it returns.
+// Each store that changed notifies once with its final value, so bindings
+// and conditions never observe a half-applied update. Batches nest; the
+// outermost one flushes.
+//
+// Example:
+//
+//	p.Batch(func() {
+//	    price.Set(42)
+//	    volume.Set(1000)
+//	    updated.Set("now")
+//	}) // bindings update once, here
+func Batch(fn func()) {
+	batchDepth++
+	defer func() {
+		batchDepth--
+		if batchDepth == 0 {
+			flushPending()
+		}
+	}()
+	fn()
+}
+
+// deferNotify reports whether notifications are currently queued instead of
+// delivered immediately.
+func deferNotify() bool {
+	return batchDepth > 0 || flushing || scheduler != ScheduleSync
+}
+
+// enqueueNotify queues a notification and requests a flush from the
+// active scheduler if none is pending.
+func enqueueNotify(fn func()) {
+	pendingNotify = append(pendingNotify, fn)
+	if batchDepth > 0 || flushing || flushRequested {
+		return
+	}
+	flushRequested = true
+	requestFlush(scheduler)
+}
+
+// flushPending delivers all queued notifications. Notifications queued
+// while flushing (e.g. a callback setting another store) run in the same flush.
+func flushPending() {
+	if flushing {
+		return
+	}
+	flushing = true
+	defer func() {
+		pendingNotify = nil
+		flushing = false
+		flushRequested = false
+	}()
+	for i := 0; i < len(pendingNotify); i++ {
+		pendingNotify[i]()
+	}
+}
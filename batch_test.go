@@ -0,0 +1,62 @@
+//go:build !wasm
+
+package preveltekit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	a := New(0)
+	b := New(0)
+	sum := Computed(func() int { return a.Get() + b.Get() })
+
+	var aCalls, sumCalls []int
+	a.OnChange(func(v int) { aCalls = append(aCalls, v) })
+	sum.OnChange(func(v int) { sumCalls = append(sumCalls, v) })
+
+	Batch(func() {
+		a.Set(1)
+		a.Set(2)
+		Batch(func() { b.Set(10) }) // nested: flushed by the outer batch
+		if len(aCalls) != 0 || len(sumCalls) != 0 {
+			t.Errorf("notified inside batch: a=%v sum=%v", aCalls, sumCalls)
+		}
+		if got := sum.Get(); got != 12 {
+			t.Errorf("sum.Get() inside batch = %d, want 12", got)
+		}
+	})
+
+	if !reflect.DeepEqual(aCalls, []int{2}) {
+		t.Errorf("a notifications = %v, want [2]", aCalls)
+	}
+	if !reflect.DeepEqual(sumCalls, []int{12}) {
+		t.Errorf("sum notifications = %v, want [12]", sumCalls)
+	}
+
+	items := NewList("x")
+	var ops []ListOpKind
+	var changes int
+	items.OnOp(func(op ListOp[string]) { ops = append(ops, op.Kind) })
+	items.OnChange(func(_ []string) { changes++ })
+	Batch(func() {
+		items.Append("y")
+		items.RemoveAt(0)
+		items.Clear()
+	})
+	if want := []ListOpKind{ListInsert, ListRemove, ListClear}; !reflect.DeepEqual(ops, want) {
+		t.Errorf("list ops = %v, want %v", ops, want)
+	}
+	if changes != 1 {
+		t.Errorf("list OnChange fired %d times, want 1", changes)
+	}
+
+	// SSR has no event loop: async schedulers flush synchronously
+	SetScheduler(ScheduleMicrotask)
+	defer SetScheduler(ScheduleSync)
+	a.Set(5)
+	if aCalls[len(aCalls)-1] != 5 {
+		t.Errorf("a notifications = %v, want last 5", aCalls)
+	}
+}
@@ -0,0 +1,133 @@
+package preveltekit
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Dispatch is an event a component emits to its parent. Declare it as a
+// struct field of the child; the parent subscribes with
+// Comp(...).OnEvent(name, handler). The event name is the field name,
+// compared case-insensitively, or the field's `event` tag.
+//
+// Handlers are func(T) or func(). A handler of any other type is a
+// programming error: rendering the component panics, naming the event and
+// both types. Emit with no subscribed handler does nothing, so a widget works
+// whether or not its parent listens.
+//
+// Example:
+//
+//	type Picker struct {
+//	    Items  *p.List[string]
+//	    Select p.Dispatch[string]
+//	    Cancel p.Dispatch[struct{}] `event:"cancel"`
+//	}
+//
+//	func (c *Picker) Render() p.Node {
+//	    return p.Ul(p.Each(c.Items, func(item string, i int) p.Node {
+//	        return p.Li(item).On("click", func() { c.Select.Emit(item) })
+//	    }))
+//	}
+//
+//	// in the parent:
+//	p.Comp(&Picker{Items: items}).OnEvent("select", func(item string) {
+//	    chosen.Set(item)
+//	})
+type Dispatch[T any] struct {
+	ids []string // handler IDs subscribed by the parent
+}
+
+// Emit calls the parent's handlers for this event with v, in subscription order.
+func (d *Dispatch[T]) Emit(v T) {
+	for _, id := range d.ids {
+		switch fn := componentHandlerRegistry[id].(type) {
+		case func(T):
+			fn(v)
+		case func():
+			fn()
+		}
+	}
+}
+
+// accepts reports whether handler can receive this event.
+func (d *Dispatch[T]) accepts(handler any) bool {
+	switch handler.(type) {
+	case func(T), func():
+		return true
+	}
+	return false
+}
+
+// Subscribed reports whether the parent handles this event.
+func (d *Dispatch[T]) Subscribed() bool {
+	return len(d.ids) > 0
+}
+
+// dispatcher is implemented by *Dispatch[T]; bindDispatchers finds the
+// component's events through it.
+type dispatcher interface {
+	bind(ids []string)
+	accepts(handler any) bool
+}
+
+func (d *Dispatch[T]) bind(ids []string) { d.ids = ids }
+
+// OnEvent subscribes handler to an event the component emits with a
+// Dispatch field. handler is func(T), matching the Dispatch[T], or func();
+// the type is checked when the component renders (see bindDispatchers).
+// The handler gets an ID from the same counter as On, so the h<N> IDs of
+// later handlers match between SSR and WASM.
+func (c *ComponentNode) OnEvent(event string, handler any) *ComponentNode {
+	id := nextHandlerID()
+	componentHandlerRegistry[id] = handler
+	c.Events = append(c.Events, &HtmlEvent{ID: id, Event: event})
+	return c
+}
+
+// bindDispatchers points the Dispatch fields of the component instance at the
+// handlers subscribed with OnEvent. It runs before the component's OnMount
+// and Render, every time the ComponentNode is rendered. It panics if a
+// handler's type does not match its Dispatch field, so the mistake shows up
+// in the first SSR build instead of as an event that never arrives.
+func (c *ComponentNode) bindDispatchers() {
+	v := reflect.ValueOf(c.Instance)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		d, ok := v.Field(i).Addr().Interface().(dispatcher)
+		if !ok {
+			continue
+		}
+		name := f.Tag.Get("event")
+		if name == "" {
+			name = f.Name
+		}
+		var ids []string
+		for _, ev := range c.Events {
+			if !strings.EqualFold(ev.Event, name) {
+				continue
+			}
+			if handler := componentHandlerRegistry[ev.ID]; !d.accepts(handler) {
+				panic("preveltekit: OnEvent(\"" + ev.Event + "\") handler " + typeName(handler) +
+					" does not match " + t.String() + "." + f.Name + " (" + f.Type.String() + ")")
+			}
+			ids = append(ids, ev.ID)
+		}
+		d.bind(ids)
+	}
+}
+
+// typeName names the type of v for error messages.
+func typeName(v any) string {
+	if v == nil {
+		return "nil"
+	}
+	return reflect.TypeOf(v).String()
+}
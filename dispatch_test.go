@@ -0,0 +1,63 @@
+//go:build !wasm
+
+package preveltekit
+
+import "testing"
+
+type dispatchPicker struct {
+	Select  Dispatch[string]
+	Cancel  Dispatch[struct{}] `event:"dismiss"`
+	Unheard Dispatch[int]
+}
+
+func (c *dispatchPicker) Render() Node {
+	return Button("Pick").On("click", func() { c.Select.Emit("b") })
+}
+
+func TestDispatch(t *testing.T) {
+	resetRegistries()
+	var selected []string
+	cancelled := 0
+	picker := &dispatchPicker{}
+	tree := Div(
+		Comp(picker).
+			OnEvent("select", func(s string) { selected = append(selected, s) }).
+			OnEvent("SELECT", func() { selected = append(selected, "any") }).
+			OnEvent("dismiss", func() { cancelled++ }),
+		Button("Other").On("click", func() {}),
+	)
+	html := nodeToHTML(tree, NewBuildContext())
+
+	// OnEvent handlers take h0-h2; the child's handler is registered in its Render
+	want := `<div><button id="h4" data-on="click">Pick</button><button id="h3" data-on="click">Other</button></div>`
+	if html != want {
+		t.Errorf("got  %s\nwant %s", html, want)
+	}
+
+	GetHandler("h4")()
+	picker.Cancel.Emit(struct{}{})
+	picker.Unheard.Emit(1)
+	if len(selected) != 2 || selected[0] != "b" || selected[1] != "any" {
+		t.Errorf("select handlers got %v", selected)
+	}
+	if cancelled != 1 {
+		t.Errorf("dismiss handler ran %d times, want 1", cancelled)
+	}
+	if !picker.Select.Subscribed() || picker.Unheard.Subscribed() {
+		t.Error("Subscribed does not reflect the parent's OnEvent calls")
+	}
+}
+
+func TestDispatchHandlerType(t *testing.T) {
+	resetRegistries()
+	tree := Comp(&dispatchPicker{}).OnEvent("select", func(n int) {})
+	defer func() {
+		want := `preveltekit: OnEvent("select") handler func(int) does not match ` +
+			`preveltekit.dispatchPicker.Select (preveltekit.Dispatch[string])`
+		if got := recover(); got != want {
+			t.Errorf("panic = %v\nwant %s", got, want)
+		}
+	}()
+	nodeToHTML(tree, NewBuildContext())
+	t.Error("rendering with a mismatched handler did not panic")
+}
@@ -0,0 +1,66 @@
+//go:build wasm
+
+package preveltekit
+
+import (
+	"os"
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+// domInstalled is set once testdata/dom.js has been loaded. The document is
+// created only once: delegated listeners stay installed on it.
+var domInstalled bool
+
+// installDOM loads the test DOM (testdata/dom.js) into the JS global scope,
+// once per test binary.
+func installDOM(t *testing.T) {
+	t.Helper()
+	if domInstalled {
+		return
+	}
+	src, err := os.ReadFile("testdata/dom.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	js.Global().Call("eval", string(src))
+	document = js.Global().Get("document")
+	domInstalled = true
+}
+
+// mount renders n the way SSR would and hydrates it (see hydrateDOM).
+func mount(t *testing.T, n Node) js.Value {
+	t.Helper()
+	return hydrateDOM(t, n, wasmNodeToHTML(n, &WASMRenderContext{}))
+}
+
+// hydrateDOM puts the pre-rendered html into the body of the test DOM and
+// wires n to it like Hydrate. The bindings are released when the test ends.
+func hydrateDOM(t *testing.T, n Node, html string) js.Value {
+	t.Helper()
+	installDOM(t)
+	body := document.Get("body")
+	body.Set("innerHTML", html)
+	cleanup := &cleanupBag{}
+	wasmWalkAndBind(n, &WASMRenderContext{}, cleanup)
+	t.Cleanup(cleanup.Release)
+	return body
+}
+
+// text returns the text content of the test DOM's body.
+func text(body js.Value) string {
+	return body.Get("textContent").String()
+}
+
+// waitFor polls cond until it holds, yielding to JS timers (requestAnimationFrame
+// and SetTimeout run on them) in between. It fails the test after a second.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,114 @@
+package preveltekit
+
+import "strings"
+
+// Event is a portable snapshot of a DOM event, passed to OnEvent handlers.
+// Fields that do not apply to the event type are left at their zero value
+// (e.g. Key for a click, ClientX for a keydown).
+//
+// In WASM the methods act on the live DOM event; in SSR builds handlers never
+// run, so the methods are no-ops and component code compiles unchanged.
+type Event struct {
+	Type string // event name, e.g. "click", "keydown", "drop"
+
+	// Keyboard
+	Key  string // logical key, e.g. "Enter", "a", "ArrowUp"
+	Code string // physical key, e.g. "KeyA", "Space"
+
+	// Modifier keys (keyboard and mouse events)
+	CtrlKey  bool
+	ShiftKey bool
+	AltKey   bool
+	MetaKey  bool
+
+	// Mouse and drag
+	Button  int // 0 = primary, 1 = middle, 2 = secondary
+	ClientX float64
+	ClientY float64
+
+	// Target element
+	TargetID string // id attribute of event.target
+	Value    string // event.target.value (inputs, selects, textareas)
+	Checked  bool   // event.target.checked (checkboxes, radios)
+
+	ref eventRef // platform handle to the native event
+}
+
+// keyAliases maps short names accepted in OnKey combos to KeyboardEvent.key
+// values (lowercased).
+var keyAliases = map[string]string{
+	"esc":   "escape",
+	"space": " ",
+	"up":    "arrowup",
+	"down":  "arrowdown",
+	"left":  "arrowleft",
+	"right": "arrowright",
+	"del":   "delete",
+	"plus":  "+",
+}
+
+// matchKeyCombo reports whether e matches a key combo like "Enter", "ctrl+s"
+// or "shift+Tab". Matching is case-insensitive. Listed modifiers must be held;
+// ctrl, alt and meta must not be held unless listed. Shift is only checked
+// when listed, so "?" matches regardless of the shift needed to type it.
+func matchKeyCombo(combo string, e Event) bool {
+	parts := splitCombo(combo)
+	if len(parts) == 0 {
+		return false
+	}
+	var ctrl, shift, alt, meta bool
+	for _, mod := range parts[:len(parts)-1] {
+		switch mod {
+		case "ctrl", "control":
+			ctrl = true
+		case "shift":
+			shift = true
+		case "alt", "option":
+			alt = true
+		case "meta", "cmd", "super":
+			meta = true
+		default:
+			return false
+		}
+	}
+	if e.CtrlKey != ctrl || e.AltKey != alt || e.MetaKey != meta || (shift && !e.ShiftKey) {
+		return false
+	}
+	key := parts[len(parts)-1]
+	if alias, ok := keyAliases[key]; ok {
+		key = alias
+	}
+	return strings.ToLower(e.Key) == key
+}
+
+// matchButton reports whether a mouse event was fired by the named button:
+// "left", "middle" or "right".
+func matchButton(name string, e Event) bool {
+	switch strings.ToLower(name) {
+	case "left":
+		return e.Button == 0
+	case "middle":
+		return e.Button == 1
+	case "right":
+		return e.Button == 2
+	}
+	return false
+}
+
+// splitCombo lowercases combo and splits it on "+". A trailing "+" is the
+// plus key itself ("ctrl++").
+func splitCombo(combo string) []string {
+	combo = strings.ToLower(combo)
+	var parts []string
+	start := 0
+	for i := 0; i < len(combo); i++ {
+		if combo[i] == '+' && i > start {
+			parts = append(parts, combo[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(combo) {
+		parts = append(parts, combo[start:])
+	}
+	return parts
+}
@@ -0,0 +1,43 @@
+//go:build !wasm
+
+package preveltekit
+
+import "testing"
+
+func TestMatchKeyCombo(t *testing.T) {
+	tests := []struct {
+		combo string
+		ev    Event
+		want  bool
+	}{
+		{"Enter", Event{Key: "Enter"}, true},
+		{"enter", Event{Key: "Enter"}, true},
+		{"Enter", Event{Key: "Enter", CtrlKey: true}, false},
+		{"ctrl+s", Event{Key: "s", CtrlKey: true}, true},
+		{"ctrl+s", Event{Key: "S", CtrlKey: true, ShiftKey: true}, true},
+		{"ctrl+s", Event{Key: "s"}, false},
+		{"ctrl+s", Event{Key: "s", CtrlKey: true, AltKey: true}, false},
+		{"shift+Tab", Event{Key: "Tab"}, false},
+		{"shift+Tab", Event{Key: "Tab", ShiftKey: true}, true},
+		{"?", Event{Key: "?", ShiftKey: true}, true},
+		{"esc", Event{Key: "Escape"}, true},
+		{"space", Event{Key: " "}, true},
+		{"cmd+k", Event{Key: "k", MetaKey: true}, true},
+		{"ctrl++", Event{Key: "+", CtrlKey: true}, true},
+		{"hyper+x", Event{Key: "x"}, false},
+	}
+	for _, tt := range tests {
+		if got := matchKeyCombo(tt.combo, tt.ev); got != tt.want {
+			t.Errorf("matchKeyCombo(%q, %+v) = %v, want %v", tt.combo, tt.ev, got, tt.want)
+		}
+	}
+}
+
+func TestMatchButton(t *testing.T) {
+	if !matchButton("left", Event{Button: 0}) || !matchButton("Right", Event{Button: 2}) {
+		t.Error("matchButton rejected a matching button")
+	}
+	if matchButton("middle", Event{Button: 0}) || matchButton("back", Event{Button: 3}) {
+		t.Error("matchButton accepted a non-matching button")
+	}
+}
@@ -0,0 +1,319 @@
+package preveltekit
+
+// FormState holds the values, validation and submit state of a form whose
+// fields map onto a struct T. Fields are registered with AddField, which
+// returns a store to Bind to an input. (The name Form is taken by the <form>
+// element constructor.)
+//
+// Validation runs eagerly on every change — and once on construction, so
+// SSR renders the initial Errors/Valid state.
+//
+// Example:
+//
+//	type Signup struct {
+//	    Email string
+//	    Age   int
+//	}
+//
+//	form := p.NewForm(Signup{})
+//	email := p.AddField(form, "email", func(s *Signup) *string { return &s.Email },
+//	    p.Required("Email is required"))
+//	age := p.AddField(form, "age", func(s *Signup) *int { return &s.Age },
+//	    p.Min(18, "You must be 18 or older"))
+//	form.OnSubmit(func(s Signup) error {
+//	    _, err := p.Post[SignupResponse]("/api/signup", s)
+//	    return err
+//	})
+//
+//	p.Form(
+//	    p.Input(p.Attr("type", "email")).Bind(email.Value).On("blur", email.Touch),
+//	    p.Span(email.Message),
+//	    p.Input(p.Attr("type", "number")).Bind(age.Value).On("blur", age.Touch),
+//	    p.Span(age.Message),
+//	    p.Button(p.Attr("type", "submit"), "Sign up").
+//	        AttrIf("disabled", p.Cond(form.Submitting.Get, form.Submitting), "disabled"),
+//	).On("submit", form.Submit).PreventDefault()
+type FormState[T any] struct {
+	Values      *Store[T]                 // current struct value, updated from the field stores
+	Errors      *Store[map[string]string] // field name → message, for every failing field
+	Touched     *Store[map[string]bool]   // field name → touched (blurred, or a submit was attempted)
+	Dirty       *Store[bool]              // any field differs from its initial value
+	Valid       *Store[bool]              // no validation errors
+	Submitting  *Store[bool]              // the OnSubmit handler is running
+	SubmitError *Store[string]            // error returned by the last OnSubmit call
+
+	initial    T
+	fields     []formField[T]
+	validators []func(T) map[string]string
+	onSubmit   func(T) error
+	run        func(fn func()) // starts the submit handler; a goroutine by default
+}
+
+// formField is the type-erased view of a Field used by FormState.
+type formField[T any] interface {
+	name() string
+	validate(v T) string
+	setError(msg string)
+	touch()
+	dirty() bool
+	reset(v T)
+}
+
+// Field is one struct field of a FormState.
+type Field[V comparable] struct {
+	Value   *Store[V]      // Bind this to the input
+	Error   *Store[string] // current validation message ("" when valid)
+	Touched *Store[bool]   // set by Touch (e.g. on blur) or a submit attempt
+	Message *Store[string] // Error once Touched, "" before — what to display
+
+	fieldName  string
+	initial    V
+	validators []Validator[V]
+	onTouch    func() // records the field in FormState.Touched
+}
+
+// Validator checks a field value and returns an error message, or "" if valid.
+type Validator[V any] func(V) string
+
+// NewForm creates a form state with the given initial value.
+func NewForm[T any](initial T) *FormState[T] {
+	f := &FormState[T]{
+		Values:      New(initial),
+		Errors:      New(map[string]string{}),
+		Touched:     New(map[string]bool{}),
+		Dirty:       New(false),
+		Valid:       New(true),
+		Submitting:  New(false),
+		SubmitError: New(""),
+		initial:     initial,
+		run:         func(fn func()) { go fn() },
+	}
+	return f
+}
+
+// AddField registers a struct field with the form. field returns a pointer
+// to the field inside a T; validators run in order and the first message wins.
+// The returned Field's Value store is kept in sync with form.Values.
+func AddField[T any, V comparable](f *FormState[T], name string, field func(*T) *V, validators ...Validator[V]) *Field[V] {
+	values := f.Values.Get()
+	initial := *field(&values)
+	fd := &Field[V]{
+		Value:      New(initial),
+		Error:      New(""),
+		Touched:    New(false),
+		fieldName:  name,
+		initial:    initial,
+		validators: validators,
+	}
+	fd.onTouch = func() {
+		touched := make(map[string]bool, len(f.fields))
+		for k, v := range f.Touched.Get() {
+			touched[k] = v
+		}
+		touched[name] = true
+		f.Touched.Set(touched)
+	}
+	fd.Message = Computed(func() string {
+		if fd.Touched.Get() {
+			return fd.Error.Get()
+		}
+		return ""
+	})
+	f.fields = append(f.fields, &boundField[T, V]{fd, field})
+
+	fd.Value.OnChange(func(v V) {
+		values := f.Values.Get()
+		*field(&values) = v
+		f.Values.Set(values)
+		f.validate()
+	})
+	f.validate()
+	return fd
+}
+
+// Touch marks the field as touched, showing its Message.
+// Use it as a blur handler: .On("blur", field.Touch).
+func (fd *Field[V]) Touch() {
+	if fd.Touched.Get() {
+		return
+	}
+	Batch(func() {
+		fd.Touched.Set(true)
+		fd.onTouch()
+	})
+}
+
+// boundField connects a Field to its location inside T.
+type boundField[T any, V comparable] struct {
+	*Field[V]
+	field func(*T) *V
+}
+
+func (b *boundField[T, V]) name() string { return b.fieldName }
+
+func (b *boundField[T, V]) dirty() bool { return b.Value.Get() != b.initial }
+
+func (b *boundField[T, V]) setError(msg string) { b.Error.Set(msg) }
+
+func (b *boundField[T, V]) touch() { b.Touch() }
+
+func (b *boundField[T, V]) validate(v T) string {
+	val := *b.field(&v)
+	for _, check := range b.validators {
+		if msg := check(val); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+func (b *boundField[T, V]) reset(v T) {
+	b.Value.Set(*b.field(&v))
+	b.Touched.Set(false)
+}
+
+// Validate adds a whole-form validator. It returns field name → message for
+// rules spanning several fields (e.g. password confirmation). Its messages
+// are merged into Errors and override per-field messages.
+//
+// Example:
+//
+//	form.Validate(func(s Signup) map[string]string {
+//	    if s.Password != s.Confirm {
+//	        return map[string]string{"confirm": "Passwords do not match"}
+//	    }
+//	    return nil
+//	})
+func (f *FormState[T]) Validate(fn func(T) map[string]string) {
+	f.validators = append(f.validators, fn)
+	f.validate()
+}
+
+// OnSubmit sets the submit handler. It runs in a goroutine, so it can call
+// Post, Put etc. directly; a returned error is stored in SubmitError.
+func (f *FormState[T]) OnSubmit(fn func(T) error) {
+	f.onSubmit = fn
+}
+
+// Submit touches every field, validates and, if the form is valid and not
+// already submitting, runs the OnSubmit handler. Use it as the form's
+// submit handler: p.Form(...).On("submit", form.Submit).PreventDefault().
+func (f *FormState[T]) Submit() {
+	Batch(func() {
+		for _, fd := range f.fields {
+			fd.touch()
+		}
+	})
+	if !f.validate() || f.onSubmit == nil || f.Submitting.Get() {
+		return
+	}
+
+	values := f.Values.Get()
+	Batch(func() {
+		f.Submitting.Set(true)
+		f.SubmitError.Set("")
+	})
+	f.run(func() {
+		err := f.onSubmit(values)
+		Batch(func() {
+			if err != nil {
+				f.SubmitError.Set(err.Error())
+			}
+			f.Submitting.Set(false)
+		})
+	})
+}
+
+// Reset restores the initial values and clears touched and submit state.
+func (f *FormState[T]) Reset() {
+	Batch(func() {
+		for _, fd := range f.fields {
+			fd.reset(f.initial)
+		}
+		f.Values.Set(f.initial)
+		f.Touched.Set(map[string]bool{})
+		f.SubmitError.Set("")
+		f.validate()
+	})
+}
+
+// validate runs all validators, publishes Errors, Valid and Dirty, and
+// reports whether the form is valid.
+func (f *FormState[T]) validate() bool {
+	values := f.Values.Get()
+	errors := make(map[string]string)
+	dirty := false
+	for _, fd := range f.fields {
+		if msg := fd.validate(values); msg != "" {
+			errors[fd.name()] = msg
+		}
+		dirty = dirty || fd.dirty()
+	}
+	for _, fn := range f.validators {
+		for name, msg := range fn(values) {
+			if msg != "" {
+				errors[name] = msg
+			}
+		}
+	}
+
+	Batch(func() {
+		for _, fd := range f.fields {
+			fd.setError(errors[fd.name()])
+		}
+		f.Errors.Set(errors)
+		f.Valid.Set(len(errors) == 0)
+		f.Dirty.Set(dirty)
+	})
+	return len(errors) == 0
+}
+
+// Required fails for empty strings.
+func Required(msg string) Validator[string] {
+	return func(v string) string {
+		if v == "" {
+			return msg
+		}
+		return ""
+	}
+}
+
+// MinLength fails for strings shorter than n bytes.
+func MinLength(n int, msg string) Validator[string] {
+	return func(v string) string {
+		if len(v) < n {
+			return msg
+		}
+		return ""
+	}
+}
+
+// MaxLength fails for strings longer than n bytes.
+func MaxLength(n int, msg string) Validator[string] {
+	return func(v string) string {
+		if len(v) > n {
+			return msg
+		}
+		return ""
+	}
+}
+
+// Min fails for numbers below min.
+func Min[V int | float64](min V, msg string) Validator[V] {
+	return func(v V) string {
+		if v < min {
+			return msg
+		}
+		return ""
+	}
+}
+
+// Max fails for numbers above max.
+func Max[V int | float64](max V, msg string) Validator[V] {
+	return func(v V) string {
+		if v > max {
+			return msg
+		}
+		return ""
+	}
+}
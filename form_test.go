@@ -0,0 +1,85 @@
+//go:build !wasm
+
+package preveltekit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestForm(t *testing.T) {
+	type signup struct {
+		Email    string
+		Age      int
+		Password string
+		Confirm  string
+	}
+	form := NewForm(signup{Age: 16})
+	email := AddField(form, "email", func(s *signup) *string { return &s.Email },
+		Required("Email is required"), MinLength(5, "Too short"))
+	age := AddField(form, "age", func(s *signup) *int { return &s.Age },
+		Min(18, "Must be 18"))
+	AddField(form, "password", func(s *signup) *string { return &s.Password })
+	confirm := AddField(form, "confirm", func(s *signup) *string { return &s.Confirm })
+	form.Validate(func(s signup) map[string]string {
+		if s.Password != s.Confirm {
+			return map[string]string{"confirm": "Passwords do not match"}
+		}
+		return nil
+	})
+
+	// Initial state is validated up front (rendered by SSR)
+	if form.Valid.Get() || email.Error.Get() != "Email is required" || age.Error.Get() != "Must be 18" {
+		t.Errorf("initial: valid=%v email=%q age=%q", form.Valid.Get(), email.Error.Get(), age.Error.Get())
+	}
+	if email.Message.Get() != "" {
+		t.Errorf("untouched field shows message %q", email.Message.Get())
+	}
+
+	email.Value.Set("abc")
+	email.Touch()
+	if email.Message.Get() != "Too short" || !form.Touched.Get()["email"] || !form.Dirty.Get() {
+		t.Errorf("after edit: message=%q touched=%v dirty=%v",
+			email.Message.Get(), form.Touched.Get(), form.Dirty.Get())
+	}
+
+	form.run = func(fn func()) { fn() } // submit synchronously
+	var submitted []signup
+	form.OnSubmit(func(s signup) error {
+		if !form.Submitting.Get() {
+			t.Error("Submitting not set while the handler runs")
+		}
+		submitted = append(submitted, s)
+		return errors.New("server down")
+	})
+
+	form.Submit() // invalid: touches everything, does not submit
+	if len(submitted) != 0 || age.Message.Get() != "Must be 18" {
+		t.Errorf("invalid submit: submitted=%v age message=%q", submitted, age.Message.Get())
+	}
+
+	email.Value.Set("a@b.cd")
+	age.Value.Set(30)
+	confirm.Value.Set("x")
+	if form.Errors.Get()["confirm"] != "Passwords do not match" {
+		t.Errorf("form validator: errors=%v", form.Errors.Get())
+	}
+	confirm.Value.Set("")
+	if !form.Valid.Get() {
+		t.Fatalf("expected valid form, errors=%v", form.Errors.Get())
+	}
+
+	form.Submit()
+	if len(submitted) != 1 || submitted[0].Email != "a@b.cd" || submitted[0].Age != 30 {
+		t.Errorf("submitted %+v", submitted)
+	}
+	if form.SubmitError.Get() != "server down" {
+		t.Errorf("SubmitError = %q", form.SubmitError.Get())
+	}
+
+	form.Reset()
+	if email.Value.Get() != "" || age.Value.Get() != 16 || form.Dirty.Get() || email.Touched.Get() {
+		t.Errorf("after reset: email=%q age=%d dirty=%v touched=%v",
+			email.Value.Get(), age.Value.Get(), form.Dirty.Get(), email.Touched.Get())
+	}
+}
@@ -20,24 +20,24 @@ func Hydrate(app ComponentRoot) {
 		app = hn.New().(ComponentRoot)
 	}
 
-	var ssrPaths []Route
-	for _, route := range app.Routes() {
-		if route.SSRPath != "" {
-			ssrPaths = append(ssrPaths, route)
-		}
-	}
+	ssrPaths := collectSSRPaths(app.Routes())
 
 	// Create output directory
 	os.MkdirAll("dist", 0755)
 
 	// Generate HTML for each SSR path with fresh state
-	for _, route := range ssrPaths {
+	for _, page := range ssrPaths {
+		if page.redirect != "" {
+			writePage(page.HTMLFile, redirectHTML(relativeURL(page.HTMLFile, page.redirect)))
+			continue
+		}
+
 		// Reset global counters so each iteration starts from s0,
 		// matching the single app.New() call in WASM.
 		resetRegistries()
 
 		// Set the SSR path before lifecycle methods
-		SetSSRPath(route.SSRPath)
+		SetSSRPath(page.Path)
 
 		// Create fresh app instance
 		var freshApp Component
@@ -67,16 +67,72 @@ func Hydrate(app ComponentRoot) {
 			ctx.CollectedStyles["app"] = scopeCSS(hs.Style(), scopeAttr)
 		}
 
-		html := nodeToHTML(freshApp.Render(), ctx)
+		tree, scope := renderIn(nil, freshApp.Render)
+		ctx.scope = scope
+		html := nodeToHTML(tree, ctx)
 
 		// Build full HTML document
 		fullHTML := buildHTMLDocument(minifyHTML(html), ctx.CollectedGlobalStyles, ctx.CollectedStyles)
 
-		// Write HTML file
-		htmlPath := filepath.Join("dist", route.HTMLFile)
-		os.WriteFile(htmlPath, []byte(fullHTML), 0644)
-		fmt.Fprintf(os.Stderr, "Generated: %s\n", htmlPath)
+		writePage(page.HTMLFile, fullHTML)
+	}
+
+	// Server-side rules for Redirect routes, for hosts that read _redirects
+	if rules := redirectRules(app.Routes()); rules != "" {
+		writePage("_redirects", rules)
+	}
+}
+
+// writePage writes a generated file below dist/.
+func writePage(file, content string) {
+	path := filepath.Join("dist", file)
+	os.MkdirAll(filepath.Dir(path), 0755)
+	os.WriteFile(path, []byte(content), 0644)
+	fmt.Fprintf(os.Stderr, "Generated: %s\n", path)
+}
+
+// redirectHTML is the pre-rendered page of a Redirect route: it sends the
+// browser to target without loading the app. Pass a target made relative
+// with relativeURL, so the page also works when the site is served below a
+// sub-path.
+func redirectHTML(target string) string {
+	url := escapeAttr(target)
+	return `<!doctype html><html><head><meta charset="utf-8">` +
+		`<meta http-equiv="refresh" content="0; url=` + url + `">` +
+		`<link rel="canonical" href="` + url + `">` +
+		`</head><body><a href="` + url + `">` + escapeHTML(target) + `</a></body></html>`
+}
+
+// relativeURL returns the root-absolute route URL target relative to the
+// page written to htmlFile: "old/1.html", "/new/1" → "../new/1".
+func relativeURL(htmlFile, target string) string {
+	up := strings.Count(strings.TrimPrefix(htmlFile, "/"), "/")
+	rel := strings.Repeat("../", up) + strings.TrimPrefix(target, "/")
+	if rel == "" || rel[0] == '?' || rel[0] == '#' {
+		rel = "./" + rel
+	}
+	return rel
+}
+
+// redirectRules returns the Redirect routes in _redirects format (Netlify,
+// Cloudflare Pages), one "from to 301" line per route, or "" if there are
+// none. Patterns with * or ** have no equivalent there and are skipped.
+// The paths are root-absolute: the rules assume the site is deployed at the
+// root of its domain, which is also where those hosts read _redirects.
+func redirectRules(routes []Route) string {
+	var sb strings.Builder
+	for _, entry := range flattenRoutes(routes, "", nil) {
+		route := entry.route()
+		if route.Redirect == "" {
+			continue
+		}
+		from := resolveRoute("/", entry.pattern)
+		if strings.Contains(from, "*") {
+			continue
+		}
+		sb.WriteString(from + " " + resolveRoute("/", route.Redirect) + " 301\n")
 	}
+	return sb.String()
 }
 
 func buildHTMLDocument(body string, collectedGlobalStyles, collectedStyles map[string]string) string {
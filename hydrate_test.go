@@ -0,0 +1,94 @@
+//go:build !wasm
+
+package preveltekit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCollectSSRPaths(t *testing.T) {
+	routes := []Route{
+		{Path: "/", HTMLFile: "index.html", SSRPath: "/"},
+		{Path: "/about", HTMLFile: "about.html"}, // no SSRPath — skipped
+		{Path: "/blog/:slug", StaticPaths: func() []StaticPath {
+			return []StaticPath{
+				{Path: "/blog/hello"},
+				{Path: "/blog/world", HTMLFile: "world.html"},
+				{Path: ""}, // ignored
+			}
+		}},
+	}
+
+	got := collectSSRPaths(routes)
+	want := []StaticPath{
+		{Path: "/", HTMLFile: "index.html"},
+		{Path: "/blog/hello", HTMLFile: "blog/hello.html"},
+		{Path: "/blog/world", HTMLFile: "world.html"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("collectSSRPaths() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].StaticPath != want[i] || got[i].redirect != "" {
+			t.Errorf("page %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHTMLFileForPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/", "index.html"},
+		{"", "index.html"},
+		{"/about", "about.html"},
+		{"/blog/hello/", "blog/hello.html"},
+		{"/docs/a/b", "docs/a/b.html"},
+	}
+	for _, tt := range tests {
+		if got := htmlFileForPath(tt.path); got != tt.want {
+			t.Errorf("htmlFileForPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRedirectRoutes(t *testing.T) {
+	routes := []Route{
+		{Path: "/new/:id", Component: &routerTestPage{"new"}},
+		{Path: "/old/:id", Redirect: "/new/:id", SSRPath: "/old/1", HTMLFile: "old/1.html"},
+		{Path: "/docs", Children: []Route{
+			{Path: "intro", Redirect: "/docs/start", SSRPath: "/docs/intro", HTMLFile: "docs/intro.html"},
+		}},
+		{Path: "/legacy/**", Redirect: "/"},
+	}
+
+	pages := collectSSRPaths(routes)
+	if len(pages) != 2 || pages[0].redirect != "/new/1" || pages[1].redirect != "/docs/start" {
+		t.Fatalf("collectSSRPaths() = %+v", pages)
+	}
+	html := redirectHTML(relativeURL(pages[0].HTMLFile, pages[0].redirect))
+	if !strings.Contains(html, `<meta http-equiv="refresh" content="0; url=../new/1">`) {
+		t.Errorf("redirectHTML lacks the meta refresh: %s", html)
+	}
+	for _, tt := range []struct{ file, target, want string }{
+		{"index.html", "/", "./"},
+		{"old.html", "/new?a=1", "new?a=1"},
+		{"docs/a/b.html", "/", "../../"},
+		{"old/1.html", "/#top", "../#top"},
+		{"index.html", "/?x=1", "./?x=1"},
+	} {
+		if got := relativeURL(tt.file, tt.target); got != tt.want {
+			t.Errorf("relativeURL(%q, %q) = %q, want %q", tt.file, tt.target, got, tt.want)
+		}
+	}
+
+	want := "/old/:id /new/:id 301\n/docs/intro /docs/start 301\n"
+	if got := redirectRules(routes); got != want {
+		t.Errorf("redirectRules() = %q, want %q", got, want)
+	}
+	if got := redirectRules(routes[:1]); got != "" {
+		t.Errorf("redirectRules() without redirects = %q", got)
+	}
+}
@@ -2,6 +2,8 @@
 
 package preveltekit
 
+import "syscall/js"
+
 // Track which if-blocks have been set up to avoid duplicates
 var setupIfBlocks = make(map[string]bool)
 
@@ -31,11 +33,13 @@ func Hydrate(app ComponentRoot) {
 	}
 
 	// Walk the Render() tree to discover and wire all bindings
+	tree, scope := renderIn(nil, app.Render)
 	ctx := &WASMRenderContext{
 		ScopeAttr: appScope,
+		scope:     scope,
 	}
 	cleanup := &cleanupBag{}
-	wasmWalkAndBind(app.Render(), ctx, cleanup)
+	wasmWalkAndBind(tree, ctx, cleanup)
 
 	// Keep WASM running
 	select {}
@@ -73,7 +77,10 @@ func wasmWalkAndBind(n Node, ctx *WASMRenderContext, cleanup *cleanupBag) {
 		wasmBindComponentNode(node, ctx, cleanup)
 
 	case *SlotNode:
-		// Slot content was already rendered, nothing to bind
+		wasmBindSlot(node, ctx, cleanup)
+
+	case *OutletNode:
+		wasmBindStoreComponent(node.Store, ctx, cleanup)
 
 	case *TextNode:
 		// Static text, no bindings needed
@@ -99,13 +106,14 @@ func wasmBindHtmlNode(h *HtmlNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 	if len(h.Events) > 0 {
 		var evts []evt
 		for _, ev := range h.Events {
-			handler := GetHandler(ev.ID)
-			if handler != nil {
+			if handler := GetHandler(ev.ID); handler != nil {
+				evts = append(evts, evt{ev.ID, ev.Event, func(Event) { handler() }})
+			} else if handler := GetEventHandler(ev.ID); handler != nil {
 				evts = append(evts, evt{ev.ID, ev.Event, handler})
 			}
 		}
 		if len(evts) > 0 {
-			bindEvents(cleanup, evts)
+			bindEvents(cleanup, elementID, evts)
 		}
 	}
 
@@ -115,10 +123,27 @@ func wasmBindHtmlNode(h *HtmlNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 	}
 
 	// Wire two-way binding
+	var bindID string
 	if h.BoundStore != nil {
 		localID := ctx.NextBindID()
-		bindID := ctx.FullID(localID)
-		wasmBindInput(bindID, h.BoundStore, cleanup)
+		bindID = ctx.FullID(localID)
+		wasmBindInput(bindID, h, cleanup)
+	}
+
+	// Resolve element ref and actions (same id choice as SSR)
+	var el Element
+	if h.needsRefID() {
+		id := elementID
+		if id == "" {
+			id = bindID
+		}
+		if id == "" {
+			id = ctx.FullID(ctx.NextRefID())
+		}
+		el = newElement(id)
+		if h.ElemRef != nil {
+			h.ElemRef.attach(el, cleanup)
+		}
 	}
 
 	// Recurse into DynAttrs then Children
@@ -131,11 +156,18 @@ func wasmBindHtmlNode(h *HtmlNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 			wasmWalkAndBind(v, ctx, cleanup)
 		case *Store[Component]:
 			wasmBindStoreComponent(v, ctx, cleanup)
-		case AnySubscriber:
+		case AnyGetter:
 			bind := &BindNode{StoreRef: v, IsHTML: false}
 			wasmBindTextNode(bind, ctx, cleanup)
 		}
 	}
+
+	// Run actions once the subtree is hydrated
+	for _, action := range h.Actions {
+		if destroy := action(el); destroy != nil {
+			cleanup.AddDestroy(destroy)
+		}
+	}
 }
 
 // wasmBindTextNode wires a text binding (BindNode) to the DOM.
@@ -145,26 +177,36 @@ func wasmBindTextNode(b *BindNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 
 	if s, ok := b.StoreRef.(AnySubscriber); ok {
 		g := b.StoreRef.(AnyGetter)
-		s.OnChangeAny(func() {
+		cleanup.AddUnsub(s.OnChangeAny(func() {
 			val := anyToString(g.GetAny())
 			if b.IsHTML {
 				replaceMarkerContent(markerID, val)
 			} else {
 				replaceMarkerContent(markerID, escapeHTML(val))
 			}
-		})
+		}))
 	}
 }
 
 // wasmBindInput wires a two-way input binding using the bind element ID.
-func wasmBindInput(bindID string, boundStore any, cleanup *cleanupBag) {
-	switch s := boundStore.(type) {
+// The element (input type, select, lazy mode) picks the DOM property and event.
+func wasmBindInput(bindID string, h *HtmlNode, cleanup *cleanupBag) {
+	event := h.bindEvent()
+	switch s := h.BoundStore.(type) {
 	case *Store[string]:
-		bindInputs(cleanup, []inp{{bindID, s}})
+		if h.isRadio() {
+			bindRadio(cleanup, bindID, s)
+		} else {
+			bindInputs(cleanup, []inp{{bindID, event, s}})
+		}
 	case *Store[int]:
-		cleanup.Add(bindInputInt(bindID, s))
+		bindInputInt(cleanup, bindID, event, s)
+	case *Store[float64]:
+		bindInputFloat(cleanup, bindID, event, s)
 	case *Store[bool]:
 		bindCheckboxes(cleanup, []chk{{bindID, s}})
+	case *Store[[]string]:
+		bindSelectMultiple(cleanup, bindID, s)
 	}
 }
 
@@ -215,14 +257,14 @@ func wasmBindAttrCond(elementID string, ac *AttrCond, cleanup *cleanupBag) {
 	updateAttr()
 
 	for _, cs := range condStores {
-		subscribeToStore(cs, updateAttr)
+		subscribeToStore(cleanup, cs, updateAttr)
 	}
 	// Also subscribe to value stores if dynamic
 	if s, ok2 := ac.TrueValue.(*Store[string]); ok2 {
-		subscribeToStore(s, updateAttr)
+		subscribeToStore(cleanup, s, updateAttr)
 	}
 	if s, ok2 := ac.FalseValue.(*Store[string]); ok2 {
-		subscribeToStore(s, updateAttr)
+		subscribeToStore(cleanup, s, updateAttr)
 	}
 }
 
@@ -256,7 +298,7 @@ func wasmBindDynAttr(da *DynAttrAttr, ctx *WASMRenderContext, cleanup *cleanupBa
 
 	for _, part := range da.Parts {
 		if _, ok := part.(string); !ok {
-			subscribeToStore(part, updateAttr)
+			subscribeToStore(cleanup, part, updateAttr)
 		}
 	}
 }
@@ -276,6 +318,7 @@ func wasmBindIfNode(ifNode *IfNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 			IDCounter:   ctx.IDCounter,
 			ScopeAttr:   ctx.ScopeAttr,
 			SlotContent: ctx.SlotContent,
+			scope:       ctx.scope,
 		}
 		wasmChildrenToHTML(branch.Children, branchCtx)
 		ctx.IDCounter = branchCtx.IDCounter
@@ -287,6 +330,7 @@ func wasmBindIfNode(ifNode *IfNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 			IDCounter:   ctx.IDCounter,
 			ScopeAttr:   ctx.ScopeAttr,
 			SlotContent: ctx.SlotContent,
+			scope:       ctx.scope,
 		}
 		wasmChildrenToHTML(ifNode.ElseNode, elseCtx)
 		ctx.IDCounter = elseCtx.IDCounter
@@ -298,7 +342,13 @@ func wasmBindIfNode(ifNode *IfNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 	}
 	setupIfBlocks[markerID] = true
 
+	// Bindings of the active branch, released on branch switch and when the
+	// enclosing subtree is released (which also allows re-binding this marker)
 	currentCleanup := &cleanupBag{}
+	cleanup.AddDestroy(func() {
+		currentCleanup.Release()
+		delete(setupIfBlocks, markerID)
+	})
 	currentBranchIdx := -2
 
 	// Collect condition stores for subscription
@@ -311,6 +361,7 @@ func wasmBindIfNode(ifNode *IfNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 
 	scopeAttr := ctx.ScopeAttr
 	slotContent := ctx.SlotContent
+	scope := ctx.scope
 
 	updateIfBlock := func() {
 		activeBranchIdx := -1
@@ -336,9 +387,10 @@ func wasmBindIfNode(ifNode *IfNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 		renderCtx := &WASMRenderContext{
 			ScopeAttr:   scopeAttr,
 			SlotContent: slotContent,
+			scope:       scope,
 		}
 		html := wasmChildrenToHTML(activeNodes, renderCtx)
-		replaceMarkerContent(markerID, html)
+		swapMarkerContent(markerID, html, ifNode.Trans)
 
 		// Release old bindings, wire new ones
 		currentCleanup.Release()
@@ -348,6 +400,7 @@ func wasmBindIfNode(ifNode *IfNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 		bindCtx := &WASMRenderContext{
 			ScopeAttr:   scopeAttr,
 			SlotContent: slotContent,
+			scope:       scope,
 		}
 		for _, child := range activeNodes {
 			wasmWalkAndBind(child, bindCtx, currentCleanup)
@@ -364,7 +417,7 @@ func wasmBindIfNode(ifNode *IfNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 			}
 			seen[sid] = true
 		}
-		subscribeToStore(store, updateIfBlock)
+		subscribeToStore(cleanup, store, updateIfBlock)
 	}
 
 	// Initial sync: wire bindings for the currently active branch
@@ -393,6 +446,7 @@ func wasmBindIfNode(ifNode *IfNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 		IDCounter:   activeCounter,
 		ScopeAttr:   scopeAttr,
 		SlotContent: slotContent,
+		scope:       scope,
 	}
 	for _, child := range activeNodes {
 		wasmWalkAndBind(child, bindCtx, currentCleanup)
@@ -400,6 +454,7 @@ func wasmBindIfNode(ifNode *IfNode, ctx *WASMRenderContext, cleanup *cleanupBag)
 }
 
 // wasmBindEachNode wires an each-block with reactive list rendering.
+// Works for any List[T] through the AnyList interface.
 func wasmBindEachNode(eachNode *EachNode, ctx *WASMRenderContext, cleanup *cleanupBag) {
 	localMarker := ctx.NextEachMarker()
 	markerID := ctx.FullID(localMarker)
@@ -409,116 +464,285 @@ func wasmBindEachNode(eachNode *EachNode, ctx *WASMRenderContext, cleanup *clean
 		return
 	}
 	setupEachBlocks[markerID] = true
+	cleanup.AddDestroy(func() { delete(setupEachBlocks, markerID) })
+
+	list, ok2 := eachNode.ListRef.(AnyList)
+	if !ok2 {
+		return
+	}
+
+	if eachNode.Key != nil {
+		wasmBindKeyedEachNode(eachNode, list, markerID, ctx, cleanup)
+		return
+	}
 
 	scopeAttr := ctx.ScopeAttr
+	scope := ctx.scope
 
-	// Render and bind items helper
-	renderAndBindItems := func(renderItems func(body func(any, int) Node) string) {
-		// Render all items to HTML
-		html := renderItems(eachNode.Body)
-		replaceMarkerContent(markerID, html)
-
-		// Re-walk each item's nodes to wire bindings
-		bindCleanup := &cleanupBag{}
-		switch list := eachNode.ListRef.(type) {
-		case *List[string]:
-			items := list.Get()
-			if len(items) == 0 && len(eachNode.ElseNode) > 0 {
-				bindCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-				for _, child := range eachNode.ElseNode {
-					wasmWalkAndBind(child, bindCtx, bindCleanup)
-				}
-			} else {
-				for i, item := range items {
-					bodyNode := eachNode.Body(item, i)
-					bindCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-					wasmWalkAndBind(bodyNode, bindCtx, bindCleanup)
-				}
+	// Bindings of the currently rendered items, released on every re-render
+	itemCleanup := &cleanupBag{}
+	cleanup.AddDestroy(func() { itemCleanup.Release() })
+
+	bindTrees := func(trees []Node) {
+		for _, tree := range trees {
+			bindCtx := &WASMRenderContext{ScopeAttr: scopeAttr, scope: scope}
+			wasmWalkAndBind(tree, bindCtx, itemCleanup)
+		}
+	}
+
+	// Subscribe to list changes: render each item once, then wire the same trees
+	cleanup.AddUnsub(list.OnChangeAny(func() {
+		trees := eachNode.trees()
+		var html string
+		for _, tree := range trees {
+			renderCtx := &WASMRenderContext{ScopeAttr: scopeAttr, scope: scope}
+			html += wasmNodeToHTML(tree, renderCtx)
+		}
+		swapMarkerContent(markerID, html, eachNode.Trans)
+
+		itemCleanup.Release()
+		itemCleanup = &cleanupBag{}
+		bindTrees(trees)
+	}))
+
+	// Wire bindings for initially rendered items (DOM already has SSR content).
+	// Reuse the trees from the HTML pass if there was one.
+	trees := eachNode.renderCache
+	eachNode.renderCache = nil
+	if trees == nil {
+		trees = eachNode.trees()
+	}
+	bindTrees(trees)
+}
+
+// keyedItem is one rendered item of a keyed each-block.
+type keyedItem struct {
+	sep     js.Value // separator comment that starts the item's DOM range
+	value   any      // item value the range was rendered from
+	cleanup *cleanupBag
+}
+
+// wasmBindKeyedEachNode wires a keyed each-block (EachKeyed).
+// Every item owns the DOM range from its separator comment up to the next
+// separator or the end marker. The ranges are worked out when the list
+// changes, as bindings inside an item may have replaced its nodes. On list
+// changes, ranges of removed keys are deleted, new keys and keys whose value
+// changed are rendered, and existing keys are moved only if they are out of
+// place.
+func wasmBindKeyedEachNode(eachNode *EachNode, list AnyList, markerID string, ctx *WASMRenderContext, cleanup *cleanupBag) {
+	startMarker := findComment(markerID + "s")
+	endMarker := findComment(markerID)
+	if startMarker.IsNull() || endMarker.IsNull() {
+		return
+	}
+	parent := endMarker.Get("parentNode")
+	sep := keyedSeparator(markerID)
+	scopeAttr := ctx.ScopeAttr
+	scope := ctx.scope
+
+	items := make(map[any]*keyedItem)
+	var order []any
+	elseShown := false
+	elseCleanup := &cleanupBag{}
+	rendered := 0 // number of items rendered so far, used for unique ID prefixes
+
+	cleanup.AddDestroy(func() {
+		for _, it := range items {
+			it.cleanup.Release()
+		}
+		elseCleanup.Release()
+	})
+
+	bindTree := func(tree Node, prefix string, c *cleanupBag) {
+		bindCtx := &WASMRenderContext{
+			IDCounter: IDCounter{Prefix: prefix},
+			ScopeAttr: scopeAttr,
+			scope:     scope,
+		}
+		wasmWalkAndBind(tree, bindCtx, c)
+	}
+
+	isSep := func(n js.Value) bool {
+		return n.Get("nodeType").Int() == 8 && n.Get("nodeValue").String() == sep
+	}
+	// rangeFrom returns the nodes from n up to the next separator or the end
+	// marker. Nodes still animating out are left where they are.
+	rangeFrom := func(n js.Value) []js.Value {
+		var nodes []js.Value
+		for ; !n.IsNull() && !n.Equal(endMarker); n = n.Get("nextSibling") {
+			if len(nodes) > 0 && isSep(n) {
+				break
 			}
-		case *List[int]:
-			items := list.Get()
-			if len(items) == 0 && len(eachNode.ElseNode) > 0 {
-				bindCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-				for _, child := range eachNode.ElseNode {
-					wasmWalkAndBind(child, bindCtx, bindCleanup)
-				}
-			} else {
-				for i, item := range items {
-					bodyNode := eachNode.Body(item, i)
-					bindCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-					wasmWalkAndBind(bodyNode, bindCtx, bindCleanup)
-				}
+			if !n.Get(leavingProp).Truthy() {
+				nodes = append(nodes, n)
 			}
 		}
+		return nodes
 	}
 
-	// Subscribe to list changes
-	switch list := eachNode.ListRef.(type) {
-	case *List[string]:
-		list.OnChange(func(items []string) {
-			renderItems := func(body func(any, int) Node) string {
-				if len(items) == 0 && len(eachNode.ElseNode) > 0 {
-					renderCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-					return wasmChildrenToHTML(eachNode.ElseNode, renderCtx)
-				}
-				var html string
-				for i, item := range items {
-					renderCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-					html += wasmNodeToHTML(body(item, i), renderCtx)
-				}
-				return html
+	// removeNodes drops an item's or the else content's DOM range, animating
+	// it out if the block has a transition
+	removeNodes := func(nodes []js.Value) {
+		if eachNode.Trans != nil {
+			leaveNodes(nodes, eachNode.Trans, nil)
+			return
+		}
+		for _, n := range nodes {
+			parent.Call("removeChild", n)
+		}
+	}
+	enter := func(nodes []js.Value) {
+		if eachNode.Trans != nil {
+			enterNodes(nodes, eachNode.Trans)
+		}
+	}
+
+	// renderItem renders value as an item and inserts its nodes before next
+	renderItem := func(value any, index int, next js.Value) (*keyedItem, []js.Value) {
+		tree := eachNode.Body(value, index)
+		prefix := keyedItemPrefix(markerID, rendered)
+		rendered++
+		itemCtx := &WASMRenderContext{
+			IDCounter: IDCounter{Prefix: prefix},
+			ScopeAttr: scopeAttr,
+			scope:     scope,
+		}
+		nodes := htmlToNodes("<!--" + sep + "-->" + wasmNodeToHTML(tree, itemCtx))
+		for _, n := range nodes {
+			parent.Call("insertBefore", n, next)
+		}
+		it := &keyedItem{sep: nodes[0], value: value, cleanup: &cleanupBag{}}
+		bindTree(tree, prefix, it.cleanup)
+		return it, nodes
+	}
+
+	// Find the separators produced by SSR (or a previous HTML pass)
+	var seps []js.Value
+	for n := startMarker.Get("nextSibling"); !n.IsNull() && !n.Equal(endMarker); n = n.Get("nextSibling") {
+		if isSep(n) {
+			seps = append(seps, n)
+		}
+	}
+
+	// Wire bindings for the initial content, reusing trees from the HTML pass
+	trees := eachNode.renderCache
+	eachNode.renderCache = nil
+	if trees == nil {
+		trees = eachNode.trees()
+	}
+	if eachNode.showsElse() {
+		elseShown = true
+		for _, tree := range trees {
+			bindTree(tree, keyedElsePrefix(markerID), elseCleanup)
+		}
+	} else {
+		// trees and seps hold the rendered items only, duplicates skipped
+		for _, item := range list.ItemsAny() {
+			if rendered >= len(trees) || rendered >= len(seps) {
+				break
+			}
+			key := eachNode.Key(item)
+			if _, dup := items[key]; dup {
+				continue
+			}
+			it := &keyedItem{sep: seps[rendered], value: item, cleanup: &cleanupBag{}}
+			bindTree(trees[rendered], keyedItemPrefix(markerID, rendered), it.cleanup)
+			rendered++
+			items[key] = it
+			order = append(order, key)
+		}
+	}
+
+	update := func() {
+		values := list.ItemsAny()
+
+		// Empty list with else content: drop all items, show else
+		if eachNode.showsElse() {
+			for _, key := range order {
+				removeNodes(rangeFrom(items[key].sep))
+				items[key].cleanup.Release()
 			}
-			renderAndBindItems(renderItems)
-		})
-	case *List[int]:
-		list.OnChange(func(items []int) {
-			renderItems := func(body func(any, int) Node) string {
-				if len(items) == 0 && len(eachNode.ElseNode) > 0 {
-					renderCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-					return wasmChildrenToHTML(eachNode.ElseNode, renderCtx)
+			items = make(map[any]*keyedItem)
+			order = nil
+			if !elseShown {
+				elseShown = true
+				elseCtx := &WASMRenderContext{
+					IDCounter: IDCounter{Prefix: keyedElsePrefix(markerID)},
+					ScopeAttr: scopeAttr,
+					scope:     scope,
 				}
-				var html string
-				for i, item := range items {
-					renderCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-					html += wasmNodeToHTML(body(item, i), renderCtx)
+				nodes := htmlToNodes(wasmChildrenToHTML(eachNode.ElseNode, elseCtx))
+				for _, n := range nodes {
+					parent.Call("insertBefore", n, endMarker)
+				}
+				enter(nodes)
+				elseCleanup = &cleanupBag{}
+				for _, tree := range eachNode.ElseNode {
+					bindTree(tree, keyedElsePrefix(markerID), elseCleanup)
 				}
-				return html
-			}
-			renderAndBindItems(renderItems)
-		})
-	}
-
-	// Wire bindings for initially rendered items (DOM already has SSR content)
-	switch list := eachNode.ListRef.(type) {
-	case *List[string]:
-		items := list.Get()
-		if len(items) == 0 && len(eachNode.ElseNode) > 0 {
-			bindCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-			for _, child := range eachNode.ElseNode {
-				wasmWalkAndBind(child, bindCtx, cleanup)
 			}
-		} else {
-			for i, item := range items {
-				bodyNode := eachNode.Body(item, i)
-				bindCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-				wasmWalkAndBind(bodyNode, bindCtx, cleanup)
+			return
+		}
+		if elseShown {
+			// The else content is everything left between the markers
+			removeNodes(rangeFrom(startMarker.Get("nextSibling")))
+			elseShown = false
+			elseCleanup.Release()
+		}
+
+		// Remove items whose key is gone
+		keep := make(map[any]bool, len(values))
+		for _, v := range values {
+			keep[eachNode.Key(v)] = true
+		}
+		for _, key := range order {
+			if !keep[key] {
+				removeNodes(rangeFrom(items[key].sep))
+				items[key].cleanup.Release()
+				delete(items, key)
 			}
 		}
-	case *List[int]:
-		items := list.Get()
-		if len(items) == 0 && len(eachNode.ElseNode) > 0 {
-			bindCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-			for _, child := range eachNode.ElseNode {
-				wasmWalkAndBind(child, bindCtx, cleanup)
+
+		// Walk the new order, inserting new items, re-rendering changed ones
+		// and moving misplaced ones. Nodes still animating out are stepped over.
+		next := skipLeaving(startMarker.Get("nextSibling"))
+		placed := make(map[any]bool, len(values))
+		order = order[:0]
+		for i, v := range values {
+			key := eachNode.Key(v)
+			if placed[key] {
+				continue // duplicate key — only the first occurrence is rendered
 			}
-		} else {
-			for i, item := range items {
-				bodyNode := eachNode.Body(item, i)
-				bindCtx := &WASMRenderContext{ScopeAttr: scopeAttr}
-				wasmWalkAndBind(bodyNode, bindCtx, cleanup)
+			placed[key] = true
+
+			it, exists := items[key]
+			switch {
+			case !exists:
+				var nodes []js.Value
+				it, nodes = renderItem(v, i, next)
+				enter(nodes)
+				items[key] = it
+			case it.value != v:
+				// Same key, new value: replace the item in place, unanimated
+				old := rangeFrom(it.sep)
+				it.cleanup.Release()
+				it, _ = renderItem(v, i, next)
+				items[key] = it
+				for _, n := range old {
+					parent.Call("removeChild", n)
+				}
+			case !it.sep.Equal(next):
+				for _, n := range rangeFrom(it.sep) {
+					parent.Call("insertBefore", n, next)
+				}
 			}
+			nodes := rangeFrom(it.sep)
+			next = skipLeaving(nodes[len(nodes)-1].Get("nextSibling"))
+			order = append(order, key)
 		}
 	}
+
+	cleanup.AddUnsub(list.OnChangeAny(update))
 }
 
 // wasmBindStoreComponent wires a Store[Component] binding.
@@ -557,7 +781,8 @@ func wasmBindStoreComponent(v *Store[Component], ctx *WASMRenderContext, cleanup
 				scopeAttr = GetOrCreateScope(name)
 				branchCtx.ScopeAttr = scopeAttr
 			}
-			tree := optComp.Render()
+			tree, scope := renderIn(ctx.scope, optComp.Render)
+			branchCtx.scope = scope
 			wasmNodeToHTML(tree, branchCtx)
 
 			rendered = append(rendered, wasmCachedOption{
@@ -565,6 +790,7 @@ func wasmBindStoreComponent(v *Store[Component], ctx *WASMRenderContext, cleanup
 				name:      name,
 				tree:      tree,
 				scopeAttr: scopeAttr,
+				scope:     scope,
 			})
 		}
 	}
@@ -576,19 +802,29 @@ func wasmBindStoreComponent(v *Store[Component], ctx *WASMRenderContext, cleanup
 	setupComponentBlocks[markerID] = true
 
 	currentCleanup := &cleanupBag{}
+	cleanup.AddDestroy(func() {
+		currentCleanup.Release()
+		delete(setupComponentBlocks, markerID)
+	})
 	currentName := ""
+	remounts := 0
 	firstCall := true
 
 	updateBlock := func() {
 		comp := v.Get()
 		if comp == nil {
+			// Nothing to hydrate: the pre-rendered DOM stays until a
+			// component is set, which then renders fresh (the initial route
+			// of a router while it loads)
+			firstCall = false
 			return
 		}
 		name := componentName(comp)
 
-		if name == currentName && !firstCall {
+		if !firstCall && blockCurrent(v, currentName, remounts) {
 			return
 		}
+		remounts = v.remounts
 
 		if firstCall {
 			firstCall = false
@@ -599,10 +835,12 @@ func wasmBindStoreComponent(v *Store[Component], ctx *WASMRenderContext, cleanup
 			// re-registering handlers with new IDs.
 			var tree Node
 			var scopeAttr string
+			var scope *componentScope
 			for _, r := range rendered {
 				if r.comp == comp {
 					tree = r.tree
 					scopeAttr = r.scopeAttr
+					scope = r.scope
 					break
 				}
 			}
@@ -613,6 +851,7 @@ func wasmBindStoreComponent(v *Store[Component], ctx *WASMRenderContext, cleanup
 			bindCtx := &WASMRenderContext{
 				IDCounter: IDCounter{Prefix: wasmChildPrefix(ctx, name)},
 				ScopeAttr: scopeAttr,
+				scope:     scope,
 			}
 			if om, ok2 := comp.(HasOnMount); ok2 {
 				om.OnMount()
@@ -624,6 +863,12 @@ func wasmBindStoreComponent(v *Store[Component], ctx *WASMRenderContext, cleanup
 			return
 		}
 
+		if name == currentName {
+			// Remount: the same component destroys its bindings before it
+			// mounts again
+			currentCleanup.Release()
+			currentCleanup = &cleanupBag{}
+		}
 		currentName = name
 
 		// Call OnMount on the new active component
@@ -632,15 +877,16 @@ func wasmBindStoreComponent(v *Store[Component], ctx *WASMRenderContext, cleanup
 		}
 
 		// Render new component to HTML (subsequent changes, not initial)
-		renderTree := comp.Render()
+		renderTree, scope := renderIn(ctx.scope, comp.Render)
 		renderCtx := &WASMRenderContext{
 			IDCounter: IDCounter{Prefix: wasmChildPrefix(ctx, name)},
+			scope:     scope,
 		}
 		if _, ok2 := comp.(HasStyle); ok2 {
 			renderCtx.ScopeAttr = GetOrCreateScope(name)
 		}
 		html := wasmNodeToHTML(renderTree, renderCtx)
-		replaceMarkerContent(markerID, html)
+		swapMarkerContent(markerID, html, v.transition)
 
 		// Release old bindings (fires OnDestroy), wire new ones
 		currentCleanup.Release()
@@ -649,6 +895,7 @@ func wasmBindStoreComponent(v *Store[Component], ctx *WASMRenderContext, cleanup
 		// Walk the same tree we just rendered (don't call Render() again)
 		bindCtx := &WASMRenderContext{
 			IDCounter: IDCounter{Prefix: wasmChildPrefix(ctx, name)},
+			scope:     scope,
 		}
 		if _, ok2 := comp.(HasStyle); ok2 {
 			bindCtx.ScopeAttr = GetOrCreateScope(name)
@@ -659,10 +906,17 @@ func wasmBindStoreComponent(v *Store[Component], ctx *WASMRenderContext, cleanup
 		wasmWalkAndBind(renderTree, bindCtx, currentCleanup)
 	}
 
-	v.OnChange(func(_ Component) { updateBlock() })
+	cleanup.AddUnsub(v.OnChange(func(_ Component) { updateBlock() }))
 	updateBlock()
 }
 
+// blockCurrent reports whether a Store[Component] block that rendered the
+// component name at the store's remount count remounts still shows v's
+// value. Then an update keeps the block's DOM.
+func blockCurrent(v *Store[Component], name string, remounts int) bool {
+	return componentName(v.Get()) == name && v.remounts == remounts
+}
+
 // wasmBindComponentNode wires a nested ComponentNode.
 func wasmBindComponentNode(c *ComponentNode, ctx *WASMRenderContext, cleanup *cleanupBag) {
 	comp, ok2 := c.Instance.(Component)
@@ -678,20 +932,28 @@ func wasmBindComponentNode(c *ComponentNode, ctx *WASMRenderContext, cleanup *cl
 		scopeAttr = GetOrCreateScope(c.Name)
 	}
 
-	// Walk slot children with parent context
-	for _, child := range c.Children {
-		wasmWalkAndBind(child, ctx, cleanup)
+	// Walk slot content (default, then named fills) with parent context
+	bindFill := func(children []Node) string {
+		for _, child := range children {
+			wasmWalkAndBind(child, ctx, cleanup)
+		}
+		return ""
 	}
 
 	// Use the cached Render() tree if available (from wasmComponentNodeToHTML
 	// during the counter-advance pass). This avoids calling Render() again,
 	// which would re-register handlers with new IDs.
-	tree := c.renderCache
+	tree, scope := c.renderCache, c.renderScope
 	if tree == nil {
-		tree = comp.Render()
+		scope = newComponentScope(ctx.scope)
+		scope.fillSlots(c, ctx.ScopeAttr, bindFill)
+		tree = scope.render(comp.Render)
+	} else {
+		c.plainFills(func(_ string, children []Node) { bindFill(children) })
 	}
 
 	// Call OnMount when the component is wired
+	c.bindDispatchers()
 	if om, ok3 := comp.(HasOnMount); ok3 {
 		om.OnMount()
 	}
@@ -705,10 +967,36 @@ func wasmBindComponentNode(c *ComponentNode, ctx *WASMRenderContext, cleanup *cl
 	childCtx := &WASMRenderContext{
 		IDCounter: IDCounter{Prefix: fullCompPrefix},
 		ScopeAttr: scopeAttr,
+		scope:     scope,
 	}
 	wasmWalkAndBind(tree, childCtx, cleanup)
 }
 
+// wasmBindSlot wires the content rendered for a slot inside the component:
+// a scoped fill's tree or the fallback. Plain fills were already wired by
+// wasmBindComponentNode with the parent's context.
+func wasmBindSlot(s *SlotNode, ctx *WASMRenderContext, cleanup *cleanupBag) {
+	scoped, _, filled := ctx.scope.slotFill(s.Name)
+	switch {
+	case scoped != nil:
+		// Reuse the tree from the HTML pass once; a later bind renders afresh
+		tree := s.renderCache
+		s.renderCache = nil
+		if tree == nil {
+			tree = scoped(s.Data)
+		}
+		fillCtx := ctx.fillContext()
+		wasmWalkAndBind(tree, fillCtx, cleanup)
+		ctx.IDCounter = fillCtx.IDCounter
+	case filled, s.Name == "" && ctx.SlotContent != "":
+		// wired by wasmBindComponentNode with the parent's context
+	default:
+		for _, child := range s.Fallback {
+			wasmWalkAndBind(child, ctx, cleanup)
+		}
+	}
+}
+
 // replaceMarkerContent replaces all DOM nodes between <!--{markerID}s--> and <!--{markerID}-->
 // with new HTML content.
 func replaceMarkerContent(markerID string, html string) {
@@ -741,30 +1029,12 @@ func replaceMarkerContent(markerID string, html string) {
 }
 
 // subscribeToStore subscribes a callback to store changes.
-func subscribeToStore(store any, callback func()) {
+// The subscription is removed when cleanup is released.
+func subscribeToStore(cleanup *cleanupBag, store any, callback func()) {
 	if s, ok := store.(AnySubscriber); ok {
-		s.OnChangeAny(callback)
+		cleanup.AddUnsub(s.OnChangeAny(callback))
 	}
 }
 
 // SetSSRPath is a no-op in WASM (only used during SSR).
 func SetSSRPath(path string) {}
-
-func atoiSafe(s string) int {
-	n := 0
-	neg := false
-	for i, c := range s {
-		if c == '-' && i == 0 {
-			neg = true
-			continue
-		}
-		if c < '0' || c > '9' {
-			break
-		}
-		n = n*10 + int(c-'0')
-	}
-	if neg {
-		return -n
-	}
-	return n
-}
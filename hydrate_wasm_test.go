@@ -0,0 +1,74 @@
+//go:build wasm
+
+package preveltekit
+
+import "testing"
+
+type keyedRow struct {
+	ID   int
+	Name string
+}
+
+func keyedRowID(r keyedRow) int { return r.ID }
+
+func TestKeyedEachUpdate(t *testing.T) {
+	resetRegistries()
+	rows := NewList(keyedRow{1, "a"}, keyedRow{2, "b"}, keyedRow{3, "c"})
+	body := mount(t, Ul(EachKeyed(rows, keyedRowID, func(r keyedRow, i int) Node {
+		return Li(r.Name, itoa(i))
+	})))
+	if got := text(body); got != "a0b1c2" {
+		t.Fatalf("initial text = %q", got)
+	}
+
+	rows.SetAt(1, keyedRow{2, "B"})
+	if got := text(body); got != "a0B1c2" {
+		t.Errorf("after SetAt: text = %q, want a0B1c2", got)
+	}
+
+	// Moved items keep their DOM; edited ones are rendered with their new index
+	li := body.Call("querySelector", "li")
+	rows.Set([]keyedRow{{3, "C"}, {1, "a"}, {2, "B"}})
+	if got := text(body); got != "C0a0B1" {
+		t.Errorf("after Set: text = %q, want C0a0B1", got)
+	}
+	if !body.Call("querySelectorAll", "li").Index(1).Equal(li) {
+		t.Error("unchanged item was re-rendered instead of moved")
+	}
+}
+
+func TestKeyedEachReactiveChild(t *testing.T) {
+	resetRegistries()
+	rows := NewList(keyedRow{1, "a"}, keyedRow{2, "b"})
+	count := New(0)
+	on := New(true)
+	body := mount(t, Ul(EachKeyed(rows, keyedRowID, func(r keyedRow, i int) Node {
+		// Top-level bindings replace the item's own nodes
+		return Fragment(Bind(count), If(Cond(on.Get, on), Li(r.Name)).Else(Li("-")))
+	}).Else(P("empty"))))
+	if got := text(body); got != "0a0b" {
+		t.Fatalf("initial text = %q", got)
+	}
+
+	count.Set(1)
+	on.Set(false)
+	rows.Set([]keyedRow{{2, "b"}, {1, "a"}})
+	if got := text(body); got != "1-1-" {
+		t.Errorf("after reorder: text = %q, want 1-1-", got)
+	}
+
+	on.Set(true)
+	rows.RemoveAt(0)
+	if got := text(body); got != "1a" {
+		t.Errorf("after RemoveAt: text = %q, want 1a", got)
+	}
+
+	rows.Clear()
+	if got := text(body); got != "empty" {
+		t.Errorf("after Clear: text = %q, want empty", got)
+	}
+	rows.Append(keyedRow{3, "c"})
+	if got := text(body); got != "1c" {
+		t.Errorf("after Append: text = %q, want 1c", got)
+	}
+}
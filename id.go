@@ -12,6 +12,7 @@ type IDCounter struct {
 	Attr   int    // Counter for dynamic attribute element IDs
 	Comp   int    // Counter for component markers
 	Route  int    // Counter for route-block markers
+	Ref    int    // Counter for element-ref element IDs
 	Prefix string // Prefix for nested components (e.g., "basics", "components_comp0")
 }
 
@@ -41,6 +42,15 @@ func (c *IDCounter) NextAttrID() string {
 	return id
 }
 
+// NextRefID returns the next element ID for element refs.
+// Only used when the element has no event, class or bind ID to reuse.
+// Used in: <canvas id="basics_ref0">
+func (c *IDCounter) NextRefID() string {
+	id := "ref" + itoa(c.Ref)
+	c.Ref++
+	return id
+}
+
 // --- Marker ID generators (for HTML comments <!--marker-->) ---
 
 // NextTextMarker returns the next marker ID for text bindings.
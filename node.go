@@ -52,7 +52,10 @@ type HtmlNode struct {
 	// Chainable bindings
 	AttrConds  []*AttrCond  // conditional attributes applied to first tag
 	Events     []*HtmlEvent // event bindings applied to first tag
-	BoundStore any          // two-way binding store (*Store[string], *Store[int], *Store[bool])
+	BoundStore any          // two-way binding store (*Store[string|int|float64|bool|[]string])
+	BindLazy   bool         // sync on "change" instead of "input"
+	ElemRef    *Ref         // element ref resolved during hydration
+	Actions    []Action     // actions run after hydration
 }
 
 // AttrCond represents a conditional attribute binding.
@@ -65,7 +68,8 @@ type AttrCond struct {
 }
 
 // HtmlEvent represents an event binding for HtmlNode.
-// Used by HtmlNode.On() to attach event handlers.
+// Used by HtmlNode.On() to attach event handlers, and by
+// ComponentNode.OnEvent() to subscribe to component events.
 type HtmlEvent struct {
 	ID    string // unique handler ID for registry lookup
 	Event string // event name (e.g., "click", "submit")
@@ -110,7 +114,6 @@ func Fragment(children ...Node) *FragmentNode {
 	return &FragmentNode{Children: children}
 }
 
-
 // =============================================================================
 // Typed Element Functions
 // =============================================================================
@@ -321,42 +324,247 @@ func (h *HtmlNode) On(event string, handler func()) *HtmlNode {
 	return h
 }
 
-// PreventDefault adds the preventDefault modifier to the last event.
-// Must be called after On. The modifier is stored in the handler registry
-// so WASM can apply event.preventDefault() without needing it in bindings.
-func (h *HtmlNode) PreventDefault() *HtmlNode {
+// OnEvent attaches an event handler that receives the DOM event.
+// Use it when the handler needs the key, pointer position, target value
+// or drag data; otherwise On is enough. Modifiers chain the same way.
+//
+// Example:
+//
+//	Input().OnEvent("keydown", func(e p.Event) {
+//	    if e.Key == "Enter" { submit(e.Value) }
+//	})
+//	Div().OnEvent("dragover", func(e p.Event) {}).PreventDefault()
+//	Div().OnEvent("drop", func(e p.Event) { add(e.Data("text/plain")) })
+func (h *HtmlNode) OnEvent(event string, handler func(Event)) *HtmlNode {
+	id := RegisterEventHandler(handler)
+	h.Events = append(h.Events, &HtmlEvent{
+		ID:    id,
+		Event: event,
+	})
+	return h
+}
+
+// OnKey attaches a keyboard handler that only runs when the key combo
+// matches, e.g. "Enter", "Escape", "ctrl+s", "shift+Tab", "meta+k".
+// Listed modifiers must be held; ctrl, alt and meta must not be held unless
+// listed. Short names esc, space, up, down, left, right, del are accepted.
+//
+// Example:
+//
+//	Input().OnKey("keydown", "Enter", c.Submit)
+//	Div().OnKey("keydown", "ctrl+s", c.Save).PreventDefault()
+func (h *HtmlNode) OnKey(event, combo string, handler func()) *HtmlNode {
+	return h.On(event, handler).modifier("key:" + combo)
+}
+
+// OnButton attaches a mouse handler that only runs for the given button:
+// "left", "middle" or "right".
+//
+// Example:
+//
+//	Div().OnButton("mousedown", "middle", c.Close)
+//	Li().OnButton("contextmenu", "right", c.ShowMenu).PreventDefault()
+func (h *HtmlNode) OnButton(event, button string, handler func()) *HtmlNode {
+	return h.On(event, handler).modifier("button:" + button)
+}
+
+// modifier appends a modifier to the last event's handler ID.
+// Modifiers live in handlerModifiers and are applied by the WASM dispatcher;
+// SSR ignores them.
+func (h *HtmlNode) modifier(mod string) *HtmlNode {
 	if len(h.Events) > 0 {
 		last := h.Events[len(h.Events)-1]
-		handlerModifiers[last.ID] = append(handlerModifiers[last.ID], "preventDefault")
+		handlerModifiers[last.ID] = append(handlerModifiers[last.ID], mod)
 	}
 	return h
 }
 
+// PreventDefault adds the preventDefault modifier to the last event.
+// Must be called after On. The modifier is stored in the handler registry
+// so WASM can apply event.preventDefault() without needing it in bindings.
+func (h *HtmlNode) PreventDefault() *HtmlNode {
+	return h.modifier("preventDefault")
+}
+
 // StopPropagation adds the stopPropagation modifier to the last event.
 // Must be called after On. The modifier is stored in the handler registry
 // so WASM can apply event.stopPropagation() without needing it in bindings.
 func (h *HtmlNode) StopPropagation() *HtmlNode {
-	if len(h.Events) > 0 {
-		last := h.Events[len(h.Events)-1]
-		handlerModifiers[last.ID] = append(handlerModifiers[last.ID], "stopPropagation")
-	}
-	return h
+	return h.modifier("stopPropagation")
+}
+
+// Once removes the last event's handler after it has run once.
+func (h *HtmlNode) Once() *HtmlNode {
+	return h.modifier("once")
+}
+
+// Capture runs the last event's handler in the capture phase: before the
+// handlers of the elements inside this one.
+func (h *HtmlNode) Capture() *HtmlNode {
+	return h.modifier("capture")
+}
+
+// Passive marks the last event's handler as passive: it promises not to call
+// preventDefault, which lets the browser scroll without waiting for it.
+// Use for touchstart, touchmove and wheel.
+func (h *HtmlNode) Passive() *HtmlNode {
+	return h.modifier("passive")
+}
+
+// Self runs the last event's handler only when the event was fired on this
+// element itself, not bubbled up from a child.
+//
+// Example:
+//
+//	Div(Attr("class", "backdrop"), dialog).On("click", c.Close).Self()
+func (h *HtmlNode) Self() *HtmlNode {
+	return h.modifier("self")
 }
 
 // Bind attaches a two-way binding to the first HTML element.
-// The store type determines the binding behavior:
-//   - *Store[string], *Store[int]: binds to input value
-//   - *Store[bool]: binds to checkbox checked state
+// The element and store type determine the binding behavior:
+//   - <input>, <textarea> with *Store[string], *Store[int], *Store[float64]: binds the value
+//   - <input type="checkbox"> with *Store[bool]: binds the checked state
+//   - <input type="radio"> with *Store[string]: all radios bound to the same
+//     store form a group; the store holds the value of the checked one
+//   - <select> with *Store[string], *Store[int], *Store[float64]: binds the selected option
+//   - <select multiple> with *Store[[]string]: binds all selected options
+//
+// SSR renders the matching value, checked and selected state.
 //
 // Example:
 //
-//	Html(`<input type="text">`).Bind(nameStore)
-//	Html(`<input type="checkbox">`).Bind(darkModeStore)
+//	Input(Attr("type", "text")).Bind(nameStore)
+//	Input(Attr("type", "checkbox")).Bind(darkModeStore)
+//	Input(Attr("type", "radio"), Attr("name", "size"), Attr("value", "s")).Bind(sizeStore)
+//	Select(Option(Attr("value", "de"), "German"), Option(Attr("value", "en"), "English")).Bind(langStore)
 func (h *HtmlNode) Bind(store any) *HtmlNode {
 	h.BoundStore = store
 	return h
 }
 
+// Lazy makes the binding sync on "change" (blur or Enter) instead of on
+// every keystroke ("input"). Selects, checkboxes and radios always use "change".
+func (h *HtmlNode) Lazy() *HtmlNode {
+	h.BindLazy = true
+	return h
+}
+
+// attrValue returns the (escaped) value of a static attribute.
+func (h *HtmlNode) attrValue(name string) (string, bool) {
+	prefix := name + `="`
+	for _, attr := range h.Attrs {
+		if strings.HasPrefix(attr, prefix) {
+			return attr[len(prefix) : len(attr)-1], true
+		}
+	}
+	return "", false
+}
+
+// isRadio reports whether the element is <input type="radio">.
+func (h *HtmlNode) isRadio() bool {
+	t, _ := h.attrValue("type")
+	return h.Tag == "input" && t == "radio"
+}
+
+// bindEvent returns the DOM event that syncs a bound element back to its store.
+func (h *HtmlNode) bindEvent() string {
+	if h.BindLazy || h.Tag == "select" {
+		return "change"
+	}
+	if t, _ := h.attrValue("type"); h.Tag == "input" && (t == "checkbox" || t == "radio") {
+		return "change"
+	}
+	return "input"
+}
+
+// bindStateAttr returns the attribute reflecting the bound store on the
+// element's opening tag: ` value="..."` for inputs, ` checked` for checked
+// checkboxes and radios. Textareas and selects carry their state in children.
+func (h *HtmlNode) bindStateAttr() string {
+	if h.Tag == "textarea" || h.Tag == "select" {
+		return ""
+	}
+	switch s := h.BoundStore.(type) {
+	case *Store[string]:
+		if h.isRadio() {
+			if v, _ := h.attrValue("value"); v == escapeAttr(s.Get()) {
+				return ` checked`
+			}
+			return ""
+		}
+		return ` value="` + escapeAttr(s.Get()) + `"`
+	case *Store[int]:
+		return ` value="` + itoa(s.Get()) + `"`
+	case *Store[float64]:
+		return ` value="` + ftoa(s.Get()) + `"`
+	case *Store[bool]:
+		if s.Get() {
+			return ` checked`
+		}
+	}
+	return ""
+}
+
+// boundSelection returns the escaped option values selected by a bound
+// <select>'s store, or nil if h is not a bound select.
+func (h *HtmlNode) boundSelection() map[string]bool {
+	if h.Tag != "select" {
+		return nil
+	}
+	switch s := h.BoundStore.(type) {
+	case *Store[string]:
+		return map[string]bool{escapeAttr(s.Get()): true}
+	case *Store[int]:
+		return map[string]bool{itoa(s.Get()): true}
+	case *Store[float64]:
+		return map[string]bool{ftoa(s.Get()): true}
+	case *Store[[]string]:
+		sel := make(map[string]bool)
+		for _, v := range s.Get() {
+			sel[escapeAttr(v)] = true
+		}
+		return sel
+	}
+	return nil
+}
+
+// withSelection returns child with ` selected` added to the <option>s whose
+// value is in sel, descending into <optgroup>. Options are shallow-copied so
+// the tree itself is not modified. Other children are returned unchanged.
+func withSelection(child any, sel map[string]bool) any {
+	opt, ok := child.(*HtmlNode)
+	if !ok {
+		return child
+	}
+	switch opt.Tag {
+	case "optgroup":
+		cp := *opt
+		cp.Children = make([]any, len(opt.Children))
+		for i, c := range opt.Children {
+			cp.Children[i] = withSelection(c, sel)
+		}
+		return &cp
+	case "option":
+		value, ok := opt.attrValue("value")
+		if !ok {
+			// Without a value attribute the option's text is its value
+			for _, c := range opt.Children {
+				if t, ok := c.(*TextNode); ok {
+					value += escapeAttr(t.Text)
+				}
+			}
+		}
+		if !sel[value] {
+			return child
+		}
+		cp := *opt
+		cp.Attrs = append(append([]string(nil), opt.Attrs...), "selected")
+		return &cp
+	}
+	return child
+}
+
 // =============================================================================
 // Bind Node (reactive text binding)
 // =============================================================================
@@ -394,6 +602,7 @@ func BindAsHTML[T any](store *Store[T]) *BindNode {
 type IfNode struct {
 	Branches []IfBranch
 	ElseNode []Node
+	Trans    *Transition // animates branch changes (nil = instant swap)
 }
 
 func (i *IfNode) nodeType() string { return "if" }
@@ -429,9 +638,12 @@ func (i *IfNode) Else(children ...Node) *IfNode {
 
 // EachNode represents list iteration with optional else for empty list.
 type EachNode struct {
-	ListRef  any                            // The actual list reference
-	Body     func(item any, index int) Node // Template function for each item
-	ElseNode []Node                         // Content for empty list
+	ListRef     any                            // The actual list reference (an AnyList)
+	Body        func(item any, index int) Node // Template function for each item
+	Key         func(item any) any             // Item key for keyed patching (nil = re-render on change)
+	ElseNode    []Node                         // Content for empty list
+	Trans       *Transition                    // animates keyed items entering and leaving
+	renderCache []Node                         // cached Body() trees (used by WASM to avoid double Body calls)
 }
 
 func (e *EachNode) nodeType() string { return "each" }
@@ -447,28 +659,106 @@ func Each[T comparable](list *List[T], body func(item T, index int) Node) *EachN
 	}
 }
 
+// EachKeyed creates a keyed list rendering node.
+// Items are tracked by the key returned from keyFn, so Append, RemoveAt and Set
+// only insert, move or remove the DOM of the affected items — focus, input state
+// and scroll position of the other items survive. An item whose value changed
+// under the same key is rendered again. Keys should be unique; of items sharing
+// a key only the first is rendered. The index passed to body is the item's
+// index when it was last rendered; moving an item does not re-render it.
+//
+// Example:
+//
+//	p.EachKeyed(todos, func(t Todo) int { return t.ID }, func(t Todo, i int) p.Node {
+//	    return p.Li(p.Input(p.Attr("type", "checkbox")), t.Title)
+//	})
+func EachKeyed[T comparable, K comparable](list *List[T], keyFn func(item T) K, body func(item T, index int) Node) *EachNode {
+	e := Each(list, body)
+	e.Key = func(item any) any {
+		return keyFn(item.(T))
+	}
+	return e
+}
+
 // Else adds content to show when the list is empty.
 func (e *EachNode) Else(children ...Node) *EachNode {
 	e.ElseNode = children
 	return e
 }
 
+// showsElse reports whether the else content is displayed instead of items.
+func (e *EachNode) showsElse() bool {
+	list, ok := e.ListRef.(AnyList)
+	return ok && len(e.ElseNode) > 0 && len(list.ItemsAny()) == 0
+}
+
+// trees calls Body for every current item, or returns the else content
+// when the list is empty. Keyed lists skip items whose key was already
+// seen, so tree n is the n-th rendered item. Returns nil if ListRef is not
+// a list.
+func (e *EachNode) trees() []Node {
+	list, ok := e.ListRef.(AnyList)
+	if !ok {
+		return nil
+	}
+	if e.showsElse() {
+		return e.ElseNode
+	}
+	items := list.ItemsAny()
+	trees := make([]Node, 0, len(items))
+	var seen map[any]bool
+	if e.Key != nil {
+		seen = make(map[any]bool, len(items))
+	}
+	for i, item := range items {
+		if seen != nil {
+			key := e.Key(item)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		trees = append(trees, e.Body(item, i))
+	}
+	return trees
+}
+
+// Keyed each-blocks give every item its own DOM range and ID prefix so items
+// can be moved or removed individually:
+//
+//	<!--e0s--><!--e0k-->item 0<!--e0k-->item 1<!--e0-->
+//
+// Item n is rendered with the prefix "e0_k{n}", else content with "e0_else".
+
+// keyedSeparator returns the comment text that starts each item's DOM range.
+func keyedSeparator(markerID string) string { return markerID + "k" }
+
+// keyedItemPrefix returns the ID prefix for the n-th rendered item of a keyed block.
+func keyedItemPrefix(markerID string, n int) string { return markerID + "_k" + itoa(n) }
+
+// keyedElsePrefix returns the ID prefix for the else content of a keyed block.
+func keyedElsePrefix(markerID string) string { return markerID + "_else" }
+
 // =============================================================================
 // Component Node (nested component)
 // =============================================================================
 
 // ComponentNode represents a nested component.
 type ComponentNode struct {
-	Name        string // Component type name (derived from instance)
-	Instance    any    // The actual component instance
-	Children    []Node // Slot content
-	renderCache Node   // cached Render() result (used by WASM to avoid double Render)
+	Name        string          // Component type name (derived from instance)
+	Instance    any             // The actual component instance
+	Children    []Node          // Default slot content
+	Fills       []*FillNode     // Named and scoped slot content
+	Events      []*HtmlEvent    // handlers for the component's Dispatch events
+	renderCache Node            // cached Render() result (used by WASM to avoid double Render)
+	renderScope *componentScope // provide scope of the cached Render()
 }
 
 func (c *ComponentNode) nodeType() string { return "component" }
 
 // Comp creates a nested component node from a component instance.
 // The component name is derived from the type via reflection.
+// Nodes in content fill the default Slot(); Fill and FillWith fill named slots.
 // Example: Comp(&Badge{Label: p.New("New")})
 func Comp(instance any, content ...any) *ComponentNode {
 	// Derive name from type
@@ -483,25 +773,146 @@ func Comp(instance any, content ...any) *ComponentNode {
 		Instance: instance,
 	}
 	for _, item := range content {
-		if v, ok := item.(Node); ok {
+		switch v := item.(type) {
+		case *FillNode:
+			c.Fills = append(c.Fills, v)
+		case Node:
 			c.Children = append(c.Children, v)
 		}
 	}
 	return c
 }
 
+// plainFills calls fn for the default content and every named (non-scoped)
+// fill, in order. SSR and both WASM passes render slot content in this order.
+func (c *ComponentNode) plainFills(fn func(name string, children []Node)) {
+	if len(c.Children) > 0 {
+		fn("", c.Children)
+	}
+	for _, f := range c.Fills {
+		if f.scoped == nil {
+			fn(f.Name, f.Children)
+		}
+	}
+}
+
 // =============================================================================
 // Slot Node (for child component content)
 // =============================================================================
 
 // SlotNode represents where child content should be inserted.
-type SlotNode struct{}
+type SlotNode struct {
+	Name        string // slot name ("" = default slot)
+	Fallback    []Node // rendered when the parent passes no content for this slot
+	Data        any    // value passed to a scoped fill (FillWith)
+	renderCache Node   // scoped fill tree from the WASM HTML pass
+}
 
 func (s *SlotNode) nodeType() string { return "slot" }
 
-// Slot creates a slot placeholder for child content.
-func Slot() *SlotNode {
-	return &SlotNode{}
+// Slot creates a placeholder for the default content passed to Comp.
+// The fallback nodes render when the parent passes none.
+func Slot(fallback ...Node) *SlotNode {
+	return &SlotNode{Fallback: fallback}
+}
+
+// SlotNamed creates a placeholder for content passed with Fill(name, ...).
+// The fallback nodes render when the parent passes none.
+//
+// Example:
+//
+//	func (c *Card) Render() p.Node {
+//	    return p.Div(p.Attr("class", "card"),
+//	        p.Header(p.SlotNamed("header", p.H2("Untitled"))),
+//	        p.Slot(),
+//	        p.Footer(p.SlotNamed("footer")),
+//	    )
+//	}
+//
+//	p.Comp(&Card{},
+//	    p.Fill("header", p.H2("Welcome")),
+//	    p.P("Body content goes to the default slot."),
+//	)
+func SlotNamed(name string, fallback ...Node) *SlotNode {
+	return &SlotNode{Name: name, Fallback: fallback}
+}
+
+// With passes data to the parent's FillWith function for this slot, which
+// makes it a scoped slot: the child decides what to show, the parent how.
+//
+// Example:
+//
+//	// in List.Render:
+//	p.Each(l.Items, func(item Item, i int) p.Node {
+//	    return p.Li(p.SlotNamed("item", p.Span(item.Name)).With(item))
+//	})
+//
+//	// in the parent:
+//	p.Comp(&List{Items: items},
+//	    p.FillWith("item", func(item Item) p.Node { return p.Strong(item.Name) }),
+//	)
+func (s *SlotNode) With(data any) *SlotNode {
+	s.Data = data
+	return s
+}
+
+// FillNode is content for a named or scoped slot, passed to Comp.
+type FillNode struct {
+	Name     string              // target slot ("" = default slot)
+	Children []Node              // content of a plain fill
+	scoped   func(data any) Node // body of a scoped fill (FillWith)
+}
+
+// Fill passes content to the child's SlotNamed(name) placeholder.
+// It is rendered in the parent's context, like default slot content.
+func Fill(name string, children ...Node) *FillNode {
+	return &FillNode{Name: name, Children: children}
+}
+
+// FillWith passes a scoped slot body: fn receives the value the child gave
+// to SlotNamed(name).With(data) and returns the content to show. fn runs in
+// the child's render, once per slot placeholder, and gets the zero T if the
+// data is not a T.
+func FillWith[T any](name string, fn func(data T) Node) *FillNode {
+	return &FillNode{Name: name, scoped: func(data any) Node {
+		v, _ := data.(T)
+		return fn(v)
+	}}
+}
+
+// fillSlots records the slot content of c in s. render renders plain fill
+// content in the parent's context and returns its HTML (the WASM bind pass
+// wires it instead and returns ""). parentAttr is the parent's CSS scope
+// class, used for scoped fills.
+func (s *componentScope) fillSlots(c *ComponentNode, parentAttr string, render func([]Node) string) {
+	s.fillAttr = parentAttr
+	c.plainFills(func(name string, children []Node) {
+		if s.slots == nil {
+			s.slots = make(map[string]string)
+		}
+		s.slots[name] = render(children)
+	})
+	for _, f := range c.Fills {
+		if f.scoped != nil {
+			if s.scoped == nil {
+				s.scoped = make(map[string]func(any) Node)
+			}
+			s.scoped[f.Name] = f.scoped
+		}
+	}
+}
+
+// slotFill returns how the slot is filled in scope s: a scoped fill body,
+// or the HTML of a plain fill (filled = true). Neither means: use the fallback.
+func (s *componentScope) slotFill(name string) (scoped func(any) Node, html string, filled bool) {
+	if s == nil {
+		return nil, "", false
+	}
+	if fn, ok := s.scoped[name]; ok {
+		return fn, "", false
+	}
+	html, filled = s.slots[name]
+	return nil, html, filled
 }
 
 // componentName returns the lowercase type name of a component.
@@ -654,6 +1065,27 @@ func ftoa(f float64) string {
 	return result
 }
 
+// atoiSafe parses a leading decimal integer, ignoring trailing garbage.
+// Returns 0 for empty or non-numeric input.
+func atoiSafe(s string) int {
+	n := 0
+	neg := false
+	for i, c := range s {
+		if c == '-' && i == 0 {
+			neg = true
+			continue
+		}
+		if c < '0' || c > '9' {
+			break
+		}
+		n = n*10 + int(c-'0')
+	}
+	if neg {
+		return -n
+	}
+	return n
+}
+
 // escapeHTML escapes HTML special characters.
 func escapeHTML(s string) string {
 	var result []byte
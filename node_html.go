@@ -24,6 +24,13 @@ type BuildContext struct {
 	// ScopeAttr is the CSS scoping class for the current component (e.g., "v0").
 	// When set, all HTML tags rendered in this context get this class injected.
 	ScopeAttr string
+
+	// scope holds the current component's Provide values and slot fills
+	scope *componentScope
+
+	// inactive is set while rendering a Store[Component] option that is not
+	// shown. OnMount is skipped there, as WASM only mounts what it hydrates.
+	inactive bool
 }
 
 // =============================================================================
@@ -61,6 +68,8 @@ func (ctx *BuildContext) Child(compID string) *BuildContext {
 	}
 	return &BuildContext{
 		IDCounter: IDCounter{Prefix: prefix},
+		scope:     ctx.scope,
+		inactive:  ctx.inactive,
 	}
 }
 
@@ -102,6 +111,11 @@ func (h *HtmlNode) renderStructured(ctx *BuildContext) string {
 		bindID = ctx.FullID(localID)
 	}
 
+	// Ref ID, only if no other ID is written
+	if h.needsRefID() && elementID == "" && bindID == "" {
+		elementID = ctx.FullID(ctx.NextRefID())
+	}
+
 	// --- Build opening tag ---
 	sb.WriteByte('<')
 	sb.WriteString(h.Tag)
@@ -185,22 +199,7 @@ func (h *HtmlNode) renderStructured(ctx *BuildContext) string {
 
 	// Write bind value/checked
 	if bindID != "" {
-		switch s := h.BoundStore.(type) {
-		case *Store[string]:
-			if h.Tag != "textarea" {
-				sb.WriteString(` value="`)
-				sb.WriteString(escapeAttr(s.Get()))
-				sb.WriteByte('"')
-			}
-		case *Store[int]:
-			sb.WriteString(` value="`)
-			sb.WriteString(itoa(s.Get()))
-			sb.WriteByte('"')
-		case *Store[bool]:
-			if s.Get() {
-				sb.WriteString(` checked`)
-			}
-		}
+		sb.WriteString(h.bindStateAttr())
 	}
 
 	// Write event data-on attr
@@ -229,7 +228,11 @@ func (h *HtmlNode) renderStructured(ctx *BuildContext) string {
 			sb.WriteString(escapeHTML(s.Get()))
 		}
 	} else {
+		sel := h.boundSelection()
 		for _, child := range h.Children {
+			if sel != nil {
+				child = withSelection(child, sel)
+			}
 			sb.WriteString(renderChild(child, ctx))
 		}
 	}
@@ -282,6 +285,7 @@ func renderStoreComponent(v *Store[Component], ctx *BuildContext) string {
 			seen[name] = true
 
 			branchCtx := ctx.Child(name)
+			branchCtx.inactive = ctx.inactive || optComp != comp
 			branchCtx.CollectedStyles = ctx.CollectedStyles
 			branchCtx.CollectedGlobalStyles = ctx.CollectedGlobalStyles
 
@@ -302,13 +306,15 @@ func renderStoreComponent(v *Store[Component], ctx *BuildContext) string {
 			}
 
 			// Call OnMount only on the active component
-			if optComp == comp {
+			if !branchCtx.inactive {
 				if om, ok := optComp.(HasOnMount); ok {
 					om.OnMount()
 				}
 			}
 
-			branchHTML := nodeToHTML(optComp.Render(), branchCtx)
+			tree, scope := renderIn(ctx.scope, optComp.Render)
+			branchCtx.scope = scope
+			branchHTML := nodeToHTML(tree, branchCtx)
 
 			if optComp == comp {
 				activeHTML = branchHTML
@@ -319,7 +325,9 @@ func renderStoreComponent(v *Store[Component], ctx *BuildContext) string {
 	} else if comp != nil {
 		name := componentName(comp)
 		childCtx := ctx.Child(name)
-		return nodeToHTML(comp.Render(), childCtx)
+		tree, scope := renderIn(ctx.scope, comp.Render)
+		childCtx.scope = scope
+		return nodeToHTML(tree, childCtx)
 	}
 	return ""
 }
@@ -378,6 +386,8 @@ func (i *IfNode) ToHTML(ctx *BuildContext) string {
 			CollectedStyles:       ctx.CollectedStyles,
 			CollectedGlobalStyles: ctx.CollectedGlobalStyles,
 			ScopeAttr:             ctx.ScopeAttr,
+			scope:                 ctx.scope,
+			inactive:              ctx.inactive,
 		}
 		branchHTML := childrenToHTML(branch.Children, branchCtx)
 		ctx.IDCounter = branchCtx.IDCounter
@@ -394,6 +404,8 @@ func (i *IfNode) ToHTML(ctx *BuildContext) string {
 			CollectedStyles:       ctx.CollectedStyles,
 			CollectedGlobalStyles: ctx.CollectedGlobalStyles,
 			ScopeAttr:             ctx.ScopeAttr,
+			scope:                 ctx.scope,
+			inactive:              ctx.inactive,
 		}
 		elseHTML := childrenToHTML(i.ElseNode, elseCtx)
 		ctx.IDCounter = elseCtx.IDCounter
@@ -412,25 +424,28 @@ func (e *EachNode) ToHTML(ctx *BuildContext) string {
 	markerID := ctx.FullID(localMarker)
 
 	var itemsHTML strings.Builder
-
-	switch list := e.ListRef.(type) {
-	case *List[string]:
-		items := list.Get()
-		if len(items) == 0 && len(e.ElseNode) > 0 {
-			itemsHTML.WriteString(childrenToHTML(e.ElseNode, ctx))
-		} else {
-			for i, item := range items {
-				itemsHTML.WriteString(nodeToHTML(e.Body(item, i), ctx))
+	if e.Key != nil {
+		// Keyed: each item gets its own separator and ID prefix
+		elseShown := e.showsElse()
+		for i, tree := range e.trees() {
+			itemCtx := &BuildContext{
+				CollectedStyles:       ctx.CollectedStyles,
+				CollectedGlobalStyles: ctx.CollectedGlobalStyles,
+				ScopeAttr:             ctx.ScopeAttr,
+				scope:                 ctx.scope,
+				inactive:              ctx.inactive,
 			}
-		}
-	case *List[int]:
-		items := list.Get()
-		if len(items) == 0 && len(e.ElseNode) > 0 {
-			itemsHTML.WriteString(childrenToHTML(e.ElseNode, ctx))
-		} else {
-			for i, item := range items {
-				itemsHTML.WriteString(nodeToHTML(e.Body(item, i), ctx))
+			if elseShown {
+				itemCtx.Prefix = keyedElsePrefix(markerID)
+			} else {
+				itemCtx.Prefix = keyedItemPrefix(markerID, i)
+				itemsHTML.WriteString("<!--" + keyedSeparator(markerID) + "-->")
 			}
+			itemsHTML.WriteString(nodeToHTML(tree, itemCtx))
+		}
+	} else {
+		for _, tree := range e.trees() {
+			itemsHTML.WriteString(nodeToHTML(tree, ctx))
 		}
 	}
 
@@ -472,31 +487,53 @@ func (c *ComponentNode) ToHTML(ctx *BuildContext) string {
 	}
 
 	// Call OnMount when the component is rendered
-	if om, ok := comp.(HasOnMount); ok {
+	c.bindDispatchers()
+	if om, ok := comp.(HasOnMount); ok && !ctx.inactive {
 		om.OnMount()
 	}
 
-	// Render slot content with current context
-	slotHTML := childrenToHTML(c.Children, ctx)
+	// Render slot content with current context, then Render with a new
+	// component scope below the parent's
+	scope := newComponentScope(ctx.scope)
+	scope.fillSlots(c, ctx.ScopeAttr, func(children []Node) string {
+		return childrenToHTML(children, ctx)
+	})
+	tree := scope.render(comp.Render)
 
 	// Create child context for the component
 	childCtx := &BuildContext{
 		IDCounter:             IDCounter{Prefix: fullCompPrefix},
-		SlotContent:           slotHTML,
+		SlotContent:           scope.slots[""],
 		CollectedStyles:       ctx.CollectedStyles,
 		CollectedGlobalStyles: ctx.CollectedGlobalStyles,
 		ScopeAttr:             scopeAttr,
+		scope:                 scope,
+		inactive:              ctx.inactive,
 	}
 
-	return nodeToHTML(comp.Render(), childCtx)
+	return nodeToHTML(tree, childCtx)
 }
 
-// ToHTML generates HTML for a slot node.
+// ToHTML generates HTML for a slot node: the parent's fill, or the fallback.
+// A scoped fill is rendered here, continuing the component's counters but
+// with the parent's CSS scope and component scope.
 func (s *SlotNode) ToHTML(ctx *BuildContext) string {
-	if ctx.SlotContent != "" {
+	scoped, html, filled := ctx.scope.slotFill(s.Name)
+	switch {
+	case scoped != nil:
+		fillCtx := *ctx
+		fillCtx.ScopeAttr = ctx.scope.fillAttr
+		fillCtx.SlotContent = ""
+		fillCtx.scope = ctx.scope.parent
+		html := nodeToHTML(scoped(s.Data), &fillCtx)
+		ctx.IDCounter = fillCtx.IDCounter
+		return html
+	case filled:
+		return html
+	case s.Name == "" && ctx.SlotContent != "":
 		return ctx.SlotContent
 	}
-	return ""
+	return childrenToHTML(s.Fallback, ctx)
 }
 
 // ToHTML generates HTML for a text node (HTML-escaped).
@@ -527,6 +564,8 @@ func nodeToHTML(n Node, ctx *BuildContext) string {
 		return node.ToHTML(ctx)
 	case *SlotNode:
 		return node.ToHTML(ctx)
+	case *OutletNode:
+		return renderStoreComponent(node.Store, ctx)
 	case *TextNode:
 		return node.ToHTML(ctx)
 	default:
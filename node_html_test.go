@@ -0,0 +1,186 @@
+//go:build !wasm
+
+package preveltekit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEachStructList(t *testing.T) {
+	type todo struct {
+		ID    int
+		Title string
+	}
+	todos := NewList(todo{1, "Write code"}, todo{2, "Ship it"})
+	node := Ul(Each(todos, func(item todo, i int) Node {
+		return Li(Itoa(item.ID), ": ", item.Title)
+	}).Else(P("Nothing to do")))
+
+	got := nodeToHTML(node, NewBuildContext())
+	want := "<ul><!--e0s--><li>1: Write code</li><li>2: Ship it</li><!--e0--></ul>"
+	if got != want {
+		t.Errorf("each over List[todo]:\n got %s\nwant %s", got, want)
+	}
+
+	todos.Clear()
+	got = nodeToHTML(node, NewBuildContext())
+	want = "<ul><!--e0s--><p>Nothing to do</p><!--e0--></ul>"
+	if got != want {
+		t.Errorf("empty each over List[todo]:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestEachKeyed(t *testing.T) {
+	type row struct {
+		ID   int
+		Name string
+	}
+	rows := NewList(row{7, "a"}, row{9, "b"})
+	count := New(0)
+	node := Ul(EachKeyed(rows, func(r row) int { return r.ID }, func(r row, i int) Node {
+		return Li(r.Name, Bind(count))
+	}).Else(P("empty", Bind(count))))
+
+	got := nodeToHTML(node, NewBuildContext())
+	want := "<ul><!--e0s-->" +
+		"<!--e0k--><li>a<!--e0_k0_t0s-->0<!--e0_k0_t0--></li>" +
+		"<!--e0k--><li>b<!--e0_k1_t0s-->0<!--e0_k1_t0--></li>" +
+		"<!--e0--></ul>"
+	if got != want {
+		t.Errorf("keyed each:\n got %s\nwant %s", got, want)
+	}
+
+	// Only the first item of a key is rendered; later items keep their
+	// prefixes in render order, as the WASM bind pass expects
+	rows.Set([]row{{7, "a"}, {7, "dup"}, {9, "b"}})
+	got = nodeToHTML(node, NewBuildContext())
+	want = "<ul><!--e0s-->" +
+		"<!--e0k--><li>a<!--e0_k0_t0s-->0<!--e0_k0_t0--></li>" +
+		"<!--e0k--><li>b<!--e0_k1_t0s-->0<!--e0_k1_t0--></li>" +
+		"<!--e0--></ul>"
+	if got != want {
+		t.Errorf("keyed each with a duplicate key:\n got %s\nwant %s", got, want)
+	}
+
+	rows.Clear()
+	got = nodeToHTML(node, NewBuildContext())
+	want = "<ul><!--e0s--><p>empty<!--e0_else_t0s-->0<!--e0_else_t0--></p><!--e0--></ul>"
+	if got != want {
+		t.Errorf("empty keyed each:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestOnEvent(t *testing.T) {
+	resetRegistries()
+	var got Event
+	node := Input().OnEvent("keydown", func(e Event) {
+		e.PreventDefault() // no-op natively
+		got = e
+	})
+
+	html := nodeToHTML(node, NewBuildContext())
+	want := `<input id="h0" data-on="keydown">`
+	if html != want {
+		t.Errorf("OnEvent html:\n got %s\nwant %s", html, want)
+	}
+
+	handler := GetEventHandler("h0")
+	if handler == nil {
+		t.Fatal("OnEvent handler not registered under h0")
+	}
+	handler(Event{Type: "keydown", Key: "Enter", Value: "hi"})
+	if got.Key != "Enter" || got.Value != "hi" || got.Data("text/plain") != "" {
+		t.Errorf("handler received %+v", got)
+	}
+}
+
+func TestRef(t *testing.T) {
+	resetRegistries()
+	canvas, input, button := NewRef(), NewRef(), NewRef()
+	name := New("")
+	node := Div(
+		Canvas().Ref(canvas),
+		Input().Bind(name).Ref(input),
+		Button("Go").On("click", func() {}).Ref(button),
+	)
+
+	ctx := NewBuildContext()
+	ctx.Prefix = "app"
+	html := nodeToHTML(node, ctx)
+	for _, want := range []string{`<canvas id="app_ref0">`, `<input id="app_b0"`, `<button id="h0"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("missing %s in %s", want, html)
+		}
+	}
+
+	// Unresolved and nil refs are safe to use
+	var nilRef *Ref
+	for _, r := range []*Ref{canvas, nilRef} {
+		if r.Mounted() {
+			t.Error("ref mounted without hydration")
+		}
+		r.Element().Focus()
+		r.Element().ScrollIntoView(true)
+		if rect := r.Element().GetBoundingRect(); rect != (Rect{}) {
+			t.Errorf("GetBoundingRect = %+v", rect)
+		}
+	}
+}
+
+func TestUse(t *testing.T) {
+	ran := false
+	autofocus := func(el Element) func() {
+		ran = true
+		el.Focus()
+		return nil
+	}
+	node := Div(Input().Use(autofocus), P("x").Use(autofocus).Ref(NewRef()))
+
+	html := nodeToHTML(node, NewBuildContext())
+	want := `<div><input id="ref0"><p id="ref1">x</p></div>`
+	if html != want {
+		t.Errorf("Use html:\n got %s\nwant %s", html, want)
+	}
+	if ran {
+		t.Error("action ran during SSR")
+	}
+}
+
+func TestBindFormState(t *testing.T) {
+	lang := New("en")
+	tags := New([]string{"go", "wasm"})
+	size := New("m")
+	price := New(9.5)
+	notes := New("a < b")
+
+	tests := []struct {
+		name string
+		node Node
+		want string
+	}{
+		{"select", Select(
+			Option(Attr("value", "de"), "German"),
+			Option(Attr("value", "en"), "English"),
+		).Bind(lang),
+			`<select id="b0"><option value="de">German</option><option value="en" selected>English</option></select>`},
+		{"select multiple", Select(Attr("multiple", "multiple"),
+			Optgroup(Attr("label", "Lang"), Option("go"), Option("rust")),
+			Option("wasm"),
+		).Bind(tags),
+			`<select id="b0" multiple="multiple"><optgroup label="Lang"><option selected>go</option><option>rust</option></optgroup><option selected>wasm</option></select>`},
+		{"radio checked", Input(Attr("type", "radio"), Attr("name", "size"), Attr("value", "m")).Bind(size),
+			`<input id="b0" type="radio" name="size" value="m" checked>`},
+		{"radio unchecked", Input(Attr("type", "radio"), Attr("name", "size"), Attr("value", "l")).Bind(size),
+			`<input id="b0" type="radio" name="size" value="l">`},
+		{"float", Input(Attr("type", "number")).Bind(price).Lazy(),
+			`<input id="b0" type="number" value="9.5">`},
+		{"textarea", Textarea().Bind(notes),
+			`<textarea id="b0">a &lt; b</textarea>`},
+	}
+	for _, tt := range tests {
+		if got := nodeToHTML(tt.node, NewBuildContext()); got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
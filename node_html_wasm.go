@@ -15,6 +15,7 @@ type WASMRenderContext struct {
 	IDCounter
 	ScopeAttr   string
 	SlotContent string
+	scope       *componentScope // values from Provide visible to the current component
 }
 
 // wasmRenderedTrees caches Render() trees from the HTML pass so the bind pass
@@ -25,6 +26,7 @@ type wasmCachedOption struct {
 	name      string
 	tree      Node
 	scopeAttr string
+	scope     *componentScope
 }
 
 var wasmRenderedTrees = make(map[string][]wasmCachedOption)
@@ -54,10 +56,9 @@ func wasmNodeToHTML(n Node, ctx *WASMRenderContext) string {
 	case *ComponentNode:
 		return wasmComponentNodeToHTML(node, ctx)
 	case *SlotNode:
-		if ctx.SlotContent != "" {
-			return ctx.SlotContent
-		}
-		return ""
+		return wasmSlotToHTML(node, ctx)
+	case *OutletNode:
+		return wasmStoreComponentToHTML(node.Store, ctx)
 	case *TextNode:
 		return escapeHTML(node.Text)
 	default:
@@ -105,6 +106,11 @@ func wasmRenderStructured(h *HtmlNode, ctx *WASMRenderContext) string {
 		bindID = ctx.FullID(localID)
 	}
 
+	// Ref ID, only if no other ID is written
+	if h.needsRefID() && elementID == "" && bindID == "" {
+		elementID = ctx.FullID(ctx.NextRefID())
+	}
+
 	// --- Build opening tag ---
 	var s string
 	s = "<" + h.Tag
@@ -179,18 +185,7 @@ func wasmRenderStructured(h *HtmlNode, ctx *WASMRenderContext) string {
 
 	// Write bind value/checked
 	if bindID != "" {
-		switch st := h.BoundStore.(type) {
-		case *Store[string]:
-			if h.Tag != "textarea" {
-				s += ` value="` + escapeAttr(st.Get()) + `"`
-			}
-		case *Store[int]:
-			s += ` value="` + itoa(st.Get()) + `"`
-		case *Store[bool]:
-			if st.Get() {
-				s += ` checked`
-			}
-		}
+		s += h.bindStateAttr()
 	}
 
 	// Write event data-on attr
@@ -218,7 +213,11 @@ func wasmRenderStructured(h *HtmlNode, ctx *WASMRenderContext) string {
 			s += escapeHTML(st.Get())
 		}
 	} else {
+		sel := h.boundSelection()
 		for _, child := range h.Children {
+			if sel != nil {
+				child = withSelection(child, sel)
+			}
 			switch v := child.(type) {
 			case Node:
 				s += wasmNodeToHTML(v, ctx)
@@ -275,7 +274,8 @@ func wasmStoreComponentToHTML(v *Store[Component], ctx *WASMRenderContext) strin
 			scopeAttr = GetOrCreateScope(name)
 			branchCtx.ScopeAttr = scopeAttr
 		}
-		tree := optComp.Render()
+		tree, scope := renderIn(ctx.scope, optComp.Render)
+		branchCtx.scope = scope
 		branchHTML := wasmNodeToHTML(tree, branchCtx)
 		if optComp == comp {
 			activeHTML = branchHTML
@@ -285,6 +285,7 @@ func wasmStoreComponentToHTML(v *Store[Component], ctx *WASMRenderContext) strin
 			name:      name,
 			tree:      tree,
 			scopeAttr: scopeAttr,
+			scope:     scope,
 		})
 	}
 
@@ -323,6 +324,7 @@ func wasmIfNodeToHTML(i *IfNode, ctx *WASMRenderContext) string {
 			IDCounter:   ctx.IDCounter,
 			ScopeAttr:   ctx.ScopeAttr,
 			SlotContent: ctx.SlotContent,
+			scope:       ctx.scope,
 		}
 		branchHTML := wasmChildrenToHTML(branch.Children, branchCtx)
 		ctx.IDCounter = branchCtx.IDCounter
@@ -338,6 +340,7 @@ func wasmIfNodeToHTML(i *IfNode, ctx *WASMRenderContext) string {
 			IDCounter:   ctx.IDCounter,
 			ScopeAttr:   ctx.ScopeAttr,
 			SlotContent: ctx.SlotContent,
+			scope:       ctx.scope,
 		}
 		elseHTML := wasmChildrenToHTML(i.ElseNode, elseCtx)
 		ctx.IDCounter = elseCtx.IDCounter
@@ -354,25 +357,26 @@ func wasmEachNodeToHTML(e *EachNode, ctx *WASMRenderContext) string {
 	localMarker := ctx.NextEachMarker()
 	markerID := ctx.FullID(localMarker)
 
+	// Cache the Body() trees so wasmBindEachNode can reuse them
+	// without calling Body() again (which would re-register handlers).
 	var itemsHTML string
-	switch list := e.ListRef.(type) {
-	case *List[string]:
-		items := list.Get()
-		if len(items) == 0 && len(e.ElseNode) > 0 {
-			itemsHTML = wasmChildrenToHTML(e.ElseNode, ctx)
-		} else {
-			for i, item := range items {
-				itemsHTML += wasmNodeToHTML(e.Body(item, i), ctx)
+	e.renderCache = e.trees()
+	if e.Key != nil {
+		// Keyed: each item gets its own separator and ID prefix (matches SSR)
+		elseShown := e.showsElse()
+		for i, tree := range e.renderCache {
+			itemCtx := &WASMRenderContext{ScopeAttr: ctx.ScopeAttr, scope: ctx.scope}
+			if elseShown {
+				itemCtx.Prefix = keyedElsePrefix(markerID)
+			} else {
+				itemCtx.Prefix = keyedItemPrefix(markerID, i)
+				itemsHTML += "<!--" + keyedSeparator(markerID) + "-->"
 			}
+			itemsHTML += wasmNodeToHTML(tree, itemCtx)
 		}
-	case *List[int]:
-		items := list.Get()
-		if len(items) == 0 && len(e.ElseNode) > 0 {
-			itemsHTML = wasmChildrenToHTML(e.ElseNode, ctx)
-		} else {
-			for i, item := range items {
-				itemsHTML += wasmNodeToHTML(e.Body(item, i), ctx)
-			}
+	} else {
+		for _, tree := range e.renderCache {
+			itemsHTML += wasmNodeToHTML(tree, ctx)
 		}
 	}
 
@@ -394,22 +398,59 @@ func wasmComponentNodeToHTML(c *ComponentNode, ctx *WASMRenderContext) string {
 		scopeAttr = GetOrCreateScope(c.Name)
 	}
 
+	c.bindDispatchers()
+
 	// Render slot content with parent context
-	slotHTML := wasmChildrenToHTML(c.Children, ctx)
+	scope := newComponentScope(ctx.scope)
+	scope.fillSlots(c, ctx.ScopeAttr, func(children []Node) string {
+		return wasmChildrenToHTML(children, ctx)
+	})
+
+	// Cache the Render() result and its component scope so wasmBindComponentNode
+	// can reuse them without calling Render() again (which would re-register handlers).
+	tree := scope.render(comp.Render)
+	c.renderCache = tree
+	c.renderScope = scope
 
 	childCtx := &WASMRenderContext{
 		IDCounter:   IDCounter{Prefix: fullCompPrefix},
 		ScopeAttr:   scopeAttr,
-		SlotContent: slotHTML,
+		SlotContent: scope.slots[""],
+		scope:       scope,
 	}
-
-	// Cache the Render() result so wasmBindComponentNode can reuse it
-	// without calling Render() again (which would re-register handlers).
-	tree := comp.Render()
-	c.renderCache = tree
 	return wasmNodeToHTML(tree, childCtx)
 }
 
+// wasmSlotToHTML renders a slot: the parent's fill, or the fallback (matches
+// SlotNode.ToHTML). A scoped fill's tree is cached for wasmBindSlot.
+func wasmSlotToHTML(s *SlotNode, ctx *WASMRenderContext) string {
+	scoped, html, filled := ctx.scope.slotFill(s.Name)
+	switch {
+	case scoped != nil:
+		s.renderCache = scoped(s.Data)
+		fillCtx := ctx.fillContext()
+		html := wasmNodeToHTML(s.renderCache, fillCtx)
+		ctx.IDCounter = fillCtx.IDCounter
+		return html
+	case filled:
+		return html
+	case s.Name == "" && ctx.SlotContent != "":
+		return ctx.SlotContent
+	}
+	return wasmChildrenToHTML(s.Fallback, ctx)
+}
+
+// fillContext returns the context for a scoped fill rendered inside the
+// component: it continues the component's counters, but uses the parent's
+// CSS scope and component scope.
+func (ctx *WASMRenderContext) fillContext() *WASMRenderContext {
+	return &WASMRenderContext{
+		IDCounter: ctx.IDCounter,
+		ScopeAttr: ctx.scope.fillAttr,
+		scope:     ctx.scope.parent,
+	}
+}
+
 // wasmAttrToHTML renders a NodeAttr to HTML string.
 func wasmAttrToHTML(attr NodeAttr, ctx *WASMRenderContext) string {
 	switch a := attr.(type) {
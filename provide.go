@@ -0,0 +1,93 @@
+package preveltekit
+
+// componentScope is the per-render state of one component instance: the
+// values it provided for its subtree and the slot content its parent passed.
+// Scopes are linked to the scope of the enclosing component; the render
+// contexts (BuildContext, WASMRenderContext) carry the current one.
+type componentScope struct {
+	parent *componentScope
+	values map[any]any // from Provide
+
+	slots    map[string]string         // rendered plain fills by slot name ("" = default slot)
+	scoped   map[string]func(any) Node // scoped fills, rendered where the slot is
+	fillAttr string                    // parent's CSS scope class, used for scoped fills
+}
+
+// rootScope holds values provided outside any Render, e.g. in the app's
+// OnMount. Every component scope descends from it.
+var rootScope = &componentScope{}
+
+// activeScope is the scope of the component whose Render is running.
+var activeScope *componentScope
+
+// Provide makes value available to the calling component and all components
+// below it, under key. Call it in Render (or the app's OnMount, which
+// provides to the whole app). Keys are compared with ==; use an unexported
+// key type to avoid collisions between packages.
+//
+// Example:
+//
+//	type themeKey struct{}
+//
+//	func (a *App) Render() p.Node {
+//	    p.Provide(themeKey{}, a.Theme)
+//	    return p.Div(p.Comp(&Layout{}))
+//	}
+//
+//	// five levels down:
+//	func (b *Badge) Render() p.Node {
+//	    theme := p.Inject[*p.Store[string]](themeKey{})
+//	    return p.Span("Theme: ", theme)
+//	}
+func Provide(key, value any) {
+	s := activeScope
+	if s == nil {
+		s = rootScope
+	}
+	if s.values == nil {
+		s.values = make(map[any]any)
+	}
+	s.values[key] = value
+}
+
+// Inject returns the value provided under key by the nearest enclosing
+// component (or the calling component itself). It returns the zero value if
+// nothing was provided or the value is not a T. Call it in Render.
+func Inject[T any](key any) T {
+	s := activeScope
+	if s == nil {
+		s = rootScope
+	}
+	for ; s != nil; s = s.parent {
+		if v, ok := s.values[key]; ok {
+			t, _ := v.(T)
+			return t
+		}
+	}
+	var zero T
+	return zero
+}
+
+// newComponentScope creates the scope of a component rendered below parent.
+func newComponentScope(parent *componentScope) *componentScope {
+	if parent == nil {
+		parent = rootScope
+	}
+	return &componentScope{parent: parent}
+}
+
+// render calls a component's Render with s active, so Provide and Inject
+// resolve against it.
+func (s *componentScope) render(fn func() Node) Node {
+	prev := activeScope
+	activeScope = s
+	defer func() { activeScope = prev }()
+	return fn()
+}
+
+// renderIn calls a component's Render with a new scope below parent active,
+// and returns the tree together with that scope for rendering the subtree.
+func renderIn(parent *componentScope, render func() Node) (Node, *componentScope) {
+	s := newComponentScope(parent)
+	return s.render(render), s
+}
@@ -0,0 +1,59 @@
+//go:build !wasm
+
+package preveltekit
+
+import "testing"
+
+type themeKey struct{}
+
+type provideOuter struct{ theme string }
+
+func (o *provideOuter) Render() Node {
+	Provide(themeKey{}, o.theme)
+	return Div(Comp(&provideMiddle{}), Comp(&provideLeaf{}))
+}
+
+type provideMiddle struct{}
+
+func (m *provideMiddle) Render() Node {
+	return Section(Comp(&provideShadow{}), Comp(&provideLeaf{}))
+}
+
+type provideShadow struct{}
+
+func (s *provideShadow) Render() Node {
+	Provide(themeKey{}, "light")
+	return Comp(&provideLeaf{})
+}
+
+type provideLeaf struct{}
+
+func (l *provideLeaf) Render() Node {
+	return Span(Inject[string](themeKey{}))
+}
+
+func TestProvideInject(t *testing.T) {
+	resetRegistries()
+	html := nodeToHTML(Comp(&provideOuter{theme: "dark"}), NewBuildContext())
+	want := `<div><section><span>light</span><span>dark</span></section><span>dark</span></div>`
+	if html != want {
+		t.Errorf("got  %s\nwant %s", html, want)
+	}
+
+	// Components rendered through a Store[Component] see the same scope
+	leaf := &provideLeaf{}
+	current := New[Component](leaf)
+	current.WithOptions(leaf)
+	Provide(themeKey{}, "root")
+	if html := nodeToHTML(Div(current), NewBuildContext()); html != `<div><!--r0s--><span>root</span><!--r0--></div>` {
+		t.Errorf("Store[Component] got %s", html)
+	}
+
+	if got := Inject[int](themeKey{}); got != 0 {
+		t.Errorf("Inject with wrong type = %d, want 0", got)
+	}
+	resetRegistries()
+	if got := Inject[string](themeKey{}); got != "" {
+		t.Errorf("root scope not reset, got %q", got)
+	}
+}
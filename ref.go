@@ -0,0 +1,104 @@
+package preveltekit
+
+// Ref gives imperative access to the DOM element of an HtmlNode, for things
+// bindings cannot express: focusing inputs, measuring layout, drawing on a
+// canvas. Attach it with .Ref(r); it resolves when the element is hydrated
+// and is cleared when the element's block is removed.
+//
+// A Ref is nil-safe: before hydration, after removal, in SSR or on a nil
+// *Ref, Element returns a zero Element whose methods do nothing.
+//
+// Example:
+//
+//	search := p.NewRef()
+//	p.Input(p.Attr("type", "search")).Ref(search)
+//	p.Button("Search").On("click", func() { search.Element().Focus() })
+type Ref struct {
+	el  Element
+	gen int // bumped on every attach, so a stale detach does not clear a newer element
+}
+
+// NewRef creates an unresolved element ref.
+func NewRef() *Ref {
+	return &Ref{}
+}
+
+// Element returns the referenced element, or a zero Element if the ref is
+// not attached to a hydrated element.
+func (r *Ref) Element() Element {
+	if r == nil {
+		return Element{}
+	}
+	return r.el
+}
+
+// Mounted reports whether the ref currently points at a hydrated element.
+func (r *Ref) Mounted() bool {
+	return r != nil && r.el.ID != ""
+}
+
+// attach resolves the ref to el and clears it when c is released.
+func (r *Ref) attach(el Element, c *cleanupBag) {
+	r.gen++
+	gen := r.gen
+	r.el = el
+	c.AddDestroy(func() {
+		if r.gen == gen {
+			r.el = Element{}
+		}
+	})
+}
+
+// Ref attaches an element ref. The element gets an id attribute so it can be
+// found during hydration.
+func (h *HtmlNode) Ref(r *Ref) *HtmlNode {
+	h.ElemRef = r
+	return h
+}
+
+// Action is a reusable element behaviour, the equivalent of Svelte's use:
+// directive. It runs once the element is hydrated and may return a cleanup
+// func (or nil), called when the element's block is removed.
+type Action func(el Element) (cleanup func())
+
+// Use attaches an action to the element. Actions run in WASM only, after
+// the element and its children are hydrated; in SSR they never run.
+//
+// Example:
+//
+//	func ClickOutside(fn func()) p.Action {
+//	    return func(el p.Element) func() {
+//	        return p.Document().On("click", func(e p.Event) {
+//	            if !el.Contains(e.Target()) {
+//	                fn()
+//	            }
+//	        })
+//	    }
+//	}
+//
+//	p.Div(menu).Use(ClickOutside(func() { open.Set(false) }))
+func (h *HtmlNode) Use(action Action) *HtmlNode {
+	h.Actions = append(h.Actions, action)
+	return h
+}
+
+// needsRefID reports whether the element must carry an id for a ref or action.
+func (h *HtmlNode) needsRefID() bool {
+	return h.ElemRef != nil || len(h.Actions) > 0
+}
+
+// Element is a portable handle to a DOM element. In WASM the methods act on
+// the live element; in SSR builds, and for a zero Element, they are no-ops.
+type Element struct {
+	ID string // id attribute of the element
+
+	ref elementRef // platform handle to the native element
+}
+
+// Rect is an element's size and position relative to the viewport
+// (getBoundingClientRect).
+type Rect struct {
+	X, Y                     float64
+	Width, Height            float64
+	Top, Right, Bottom, Left float64
+}
@@ -11,10 +11,24 @@ import (
 type Router struct {
 	componentStore *Store[Component]
 	routes         []Route
+	entries        []routeEntry        // flattened route tree, matched by handleRoute
+	outlets        []*Store[Component] // component store per nesting depth; [0] is componentStore
+	emptyOutlet    Component           // shown in outlets below the matched route
 	id             string
-	basePath       string // detected at Start(), used to resolve relative route paths
+	mode           RouterMode // HistoryRouting or HashRouting
+	basePath       string     // detected at Start(), used to resolve relative route paths
 	notFound       func()
 	currentPath    *Store[string]
+	params         *Store[map[string]string]  // named segments captured from the matched route
+	paramStores    map[string]*Store[string]  // per-key views of params, created lazily by Param()
+	query          *Query                     // query string of the current URL
+	hash           *Store[string]             // fragment of the current URL, without "#"
+	data           *Store[any]                // result of the current route's Load
+	loading        *Store[bool]               // guards or loader running
+	loadError      *Store[string]             // error of the current route's Load
+	navSeq         int                        // bumped per navigation; stale loader results are dropped
+	redirects      int                        // consecutive guard redirects, bounded by maxRedirects
+	started        bool                       // initial route handled
 	beforeNav      func(from, to string) bool // return false to cancel navigation
 	linksSetup     bool                       // tracks if click listener is already registered
 	clickFn        js.Func                    // retained to prevent GC
@@ -23,20 +37,29 @@ type Router struct {
 
 // NewRouter creates a new router instance with a component store, routes, and ID.
 // Automatically registers all route components as options on the component store
-// so SSR can pre-render all branches.
-func NewRouter(componentStore *Store[Component], routes []Route, id string) *Router {
-	// Register all route components as store options for pre-baked rendering
-	for _, route := range routes {
-		if route.Component != nil {
-			componentStore.WithOptions(route.Component)
-		}
-	}
-	return &Router{
+// so SSR can pre-render all branches. Pass HashRouting to keep the route in
+// the URL fragment (index.html#/user/5) instead of the path.
+func NewRouter(componentStore *Store[Component], routes []Route, id string, mode ...RouterMode) *Router {
+	r := &Router{
 		componentStore: componentStore,
 		routes:         routes,
+		entries:        flattenRoutes(routes, "", nil),
+		emptyOutlet:    &outletEmpty{},
 		id:             id,
+		mode:           routerMode(mode),
 		currentPath:    newWithID(id+".path", ""),
+		params:         newWithID(id+".params", map[string]string{}),
+		hash:           newWithID(id+".hash", ""),
+		data:           newWithID[any](id+".data", nil),
+		loading:        newWithID(id+".loading", false),
+		loadError:      newWithID(id+".loadError", ""),
 	}
+	r.query = newQuery(r)
+	// Register all route components as options of their outlet store for
+	// pre-baked rendering, and let the top-level layouts find the first outlet
+	r.outlets = newOutlets(componentStore, routes, id, r.emptyOutlet)
+	Provide(outletKey{}, outletLevel{router: r, depth: 1})
+	return r
 }
 
 // NotFound sets the handler for unmatched routes
@@ -50,7 +73,8 @@ func (r *Router) BeforeNavigate(fn func(from, to string) bool) {
 	r.beforeNav = fn
 }
 
-// CurrentPath returns a store containing the current path
+// CurrentPath returns a store containing the current route path. In hash
+// mode that is the path in the fragment ("/user/5" for index.html#/user/5).
 func (r *Router) CurrentPath() *Store[string] {
 	return r.currentPath
 }
@@ -58,15 +82,20 @@ func (r *Router) CurrentPath() *Store[string] {
 // detectBasePath determines the base path by matching the current pathname
 // against route SSRPaths. E.g., if pathname is "/preveltekit/manual" and a
 // route has SSRPath "/manual", the base path is "/preveltekit".
+// In hash mode routes live in the fragment, so the base path is always "/".
 func (r *Router) detectBasePath(pathname string) string {
+	if r.mode == HashRouting {
+		return "/"
+	}
+
 	// Normalize: strip trailing slash for matching (unless root)
 	norm := pathname
 	if len(norm) > 1 && norm[len(norm)-1] == '/' {
 		norm = norm[:len(norm)-1]
 	}
 
-	for _, route := range r.routes {
-		ssr := route.SSRPath
+	for i := range r.entries {
+		ssr := r.entries[i].route().SSRPath
 		if ssr == "" {
 			continue
 		}
@@ -83,6 +112,21 @@ func (r *Router) detectBasePath(pathname string) string {
 			return base
 		}
 	}
+	// Pages pre-rendered via StaticPaths have no single SSRPath — match the
+	// route pattern against successively shorter suffixes of the pathname.
+	segs := splitPath(norm)
+	for _, entry := range r.entries {
+		route := entry.route()
+		if route.SSRPath != "" || route.StaticPaths == nil {
+			continue
+		}
+		pattern := "/" + strings.TrimPrefix(entry.pattern, "/")
+		for i := range segs {
+			if _, _, ok := matchRoute(pattern, "/"+strings.Join(segs[i:], "/")); ok {
+				return "/" + strings.Join(segs[:i], "/")
+			}
+		}
+	}
 	// Fallback: the whole pathname is the base (root route matched)
 	return norm
 }
@@ -90,25 +134,54 @@ func (r *Router) detectBasePath(pathname string) string {
 // Start initializes the router and handles the current URL
 func (r *Router) Start() {
 	// Handle initial route
-	path := js.Global().Get("location").Get("pathname").String()
-	r.basePath = r.detectBasePath(path)
-	r.currentPath.Set(path)
-	r.handleRoute(path)
+	pathname := js.Global().Get("location").Get("pathname").String()
+	r.basePath = r.detectBasePath(pathname)
+	route := r.locationURL()
+	if r.mode == HashRouting {
+		// The document is a pre-rendered page, but the fragment may name any
+		// route: hydrate the page as rendered, then switch to the route.
+		page := r.prerenderedPath(pathname)
+		r.handleRoute(page)
+		if route != page {
+			SetTimeout(0, func() { r.handleRoute(route) })
+		}
+	} else {
+		r.handleRoute(route)
+	}
+	r.started = true
 
-	// Listen for popstate (back/forward)
+	// Listen for popstate (back/forward, in-page #fragment links, and
+	// edits of the fragment in hash mode)
 	r.popstateFn = js.FuncOf(func(this js.Value, args []js.Value) any {
-		path := js.Global().Get("location").Get("pathname").String()
-		r.handleRoute(path)
+		r.handleRoute(r.locationURL())
 		return nil
 	})
 	js.Global().Call("addEventListener", "popstate", r.popstateFn)
 
+	// Write fragments set through Hash() back to the URL
+	r.hash.OnChange(func(hash string) {
+		path, query, current := splitURL(r.locationURL())
+		if hash == current {
+			return
+		}
+		url := path
+		if query != "" {
+			url += "?" + query
+		}
+		if hash != "" {
+			url += "#" + hash
+		}
+		js.Global().Get("history").Call("replaceState", nil, "", r.href(url))
+	})
+
 	// Intercept all link clicks for SPA navigation
 	r.SetupLinks()
 }
 
 // SetupLinks intercepts clicks on all internal anchor elements for SPA navigation
 // This is called automatically by Start(). Safe to call multiple times.
+// The listener is on window, so it runs after the delegated event handlers on
+// document, and a click whose handler stopped propagation is not navigated.
 func (r *Router) SetupLinks() {
 	if r.linksSetup {
 		return
@@ -169,20 +242,36 @@ func (r *Router) SetupLinks() {
 			return nil
 		}
 
-		// Skip hash-only links
-		if hrefStr == "#" || (strings.HasPrefix(hrefStr, "#") && len(hrefStr) > 1) {
+		// Skip hash-only links; in hash mode "#/path" is a route and
+		// "#section" an anchor within the current route
+		if hrefStr == "#" {
+			return nil
+		}
+		if strings.HasPrefix(hrefStr, "#") {
+			if r.mode != HashRouting {
+				return nil
+			}
+			e.Call("preventDefault")
+			if strings.HasPrefix(hrefStr, "#/") {
+				r.Navigate(hrefStr[1:])
+			} else {
+				r.hash.Set(hrefStr[1:])
+				if el := js.Global().Get("document").Call("getElementById", hrefStr[1:]); !el.IsNull() {
+					el.Call("scrollIntoView")
+				}
+			}
 			return nil
 		}
 
 		e.Call("preventDefault")
 
 		// Resolve and navigate
-		path := resolvePath(hrefStr)
+		path := resolvePath(hrefStr, r.routePath())
 		r.Navigate(path)
 
 		return nil
 	})
-	js.Global().Get("document").Call("addEventListener", "click", r.clickFn)
+	js.Global().Call("addEventListener", "click", r.clickFn)
 }
 
 // Navigate programmatically navigates to a path
@@ -194,11 +283,12 @@ func (r *Router) Navigate(path string) {
 		return
 	}
 
-	js.Global().Get("history").Call("pushState", nil, "", path)
+	js.Global().Get("history").Call("pushState", nil, "", r.href(path))
 	r.handleRoute(path)
 }
 
-// Replace navigates without adding to history
+// Replace navigates like Navigate, but replaces the current history entry
+// instead of adding one
 func (r *Router) Replace(path string) {
 	currentPath := r.currentPath.Get()
 
@@ -206,12 +296,15 @@ func (r *Router) Replace(path string) {
 		return
 	}
 
-	// Use replaceState then navigate to avoid adding to history
-	js.Global().Get("history").Call("replaceState", nil, "", path)
-	js.Global().Get("location").Call("replace", path)
+	js.Global().Get("history").Call("replaceState", nil, "", r.href(path))
+	r.handleRoute(path)
 }
 
+// handleRoute matches the path and sets the components. A query string or
+// fragment in path is published to Query() and Hash().
 func (r *Router) handleRoute(path string) {
+	path, query, hash := splitURL(path)
+
 	// Normalize path
 	if path == "" {
 		path = "/"
@@ -220,42 +313,104 @@ func (r *Router) handleRoute(path string) {
 		path = path[:len(path)-1]
 	}
 
+	// An in-page #anchor (popstate after the browser followed it) keeps the
+	// route: no guards, loaders or Pending
+	if r.fragmentOnly(path, query, hash) {
+		r.hash.Set(hash)
+		return
+	}
+
+	r.setURLState(query, hash)
 	r.currentPath.Set(path)
 
 	// Find matching route (most specific first)
-	// Each route's Path is resolved against the base path before matching.
-	var bestMatch *Route
-	bestSpecificity := -1
-
-	for i := range r.routes {
-		route := &r.routes[i]
-		resolved := resolveRoute(r.basePath, route.Path)
-		_, specificity, ok := matchRoute(resolved, path)
-		if ok && specificity > bestSpecificity {
-			bestMatch = route
-			bestSpecificity = specificity
-		}
+	entry, params := r.matchPath(path)
+
+	// Run guards and loader, then show the route
+	r.enter(entry, params, path)
+}
+
+// fragmentOnly reports whether a URL differs from the current one in its
+// fragment only.
+func (r *Router) fragmentOnly(path, query, hash string) bool {
+	return r.started && path == r.currentPath.Get() && hash != r.hash.Get() &&
+		encodeQuery(parseQuery(query)) == encodeQuery(r.query.Values().Get())
+}
+
+// background runs a navigation's guards and loader in a goroutine, as they
+// may call Fetch. This includes the initial route: hydration does not wait
+// for it, and the pre-rendered page stays until it has resolved.
+func (r *Router) background(fn func()) {
+	go fn()
+}
+
+// replaceURL replaces the current history entry, for redirects.
+func (r *Router) replaceURL(url string) {
+	js.Global().Get("history").Call("replaceState", nil, "", r.href(url))
+}
+
+// writeQuery puts a query string set through Query() into the URL and
+// publishes it. The route is not matched again; the path stays the same.
+func (r *Router) writeQuery(query string, replace bool) {
+	url := r.currentPath.Get() + query
+	if hash := r.hash.Get(); hash != "" {
+		url += "#" + hash
+	}
+	method := "pushState"
+	if replace {
+		method = "replaceState"
 	}
+	js.Global().Get("history").Call(method, nil, "", r.href(url))
+	r.setURLState(strings.TrimPrefix(query, "?"), r.hash.Get())
+}
 
-	if bestMatch != nil && bestMatch.Component != nil {
-		r.componentStore.Set(bestMatch.Component)
-	} else if r.notFound != nil {
-		r.notFound()
+// locationURL returns the route URL of the current location: path, query
+// string and fragment, or in hash mode the route held in the fragment.
+func (r *Router) locationURL() string {
+	location := js.Global().Get("location")
+	if r.mode == HashRouting {
+		fragment := location.Get("hash").String()
+		if route, ok := hashRoute(fragment); ok {
+			return route
+		}
+		// An anchor ("#section"): the route stays, with the anchor as its fragment
+		path := r.currentPath.Get()
+		if path == "" {
+			path = r.prerenderedPath(location.Get("pathname").String())
+		}
+		return path + encodeQuery(r.query.Values().Get()) + fragment
 	}
+	return location.Get("pathname").String() + location.Get("search").String() + location.Get("hash").String()
+}
+
+// routePath returns the path of the current route URL, which relative links
+// resolve against.
+func (r *Router) routePath() string {
+	path, _, _ := splitURL(r.locationURL())
+	return path
 }
 
-// resolvePath resolves a relative or absolute href to an absolute path
-func resolvePath(href string) string {
+// resolvePath resolves a relative or absolute href against the current
+// route path. A query string or fragment is kept; "?page=2" resolves
+// against the current path.
+func resolvePath(href, current string) string {
 	if len(href) > 0 && href[0] == '/' {
 		return href
 	}
 
 	if href == "" || href == "#" {
-		return js.Global().Get("location").Get("pathname").String()
+		return current
+	}
+
+	// Resolve the path part only
+	suffix := ""
+	if i := strings.IndexAny(href, "?#"); i >= 0 {
+		href, suffix = href[:i], href[i:]
+	}
+	if href == "" {
+		return current + suffix
 	}
 
-	// Get current path
-	current := js.Global().Get("location").Get("pathname").String()
 	if !strings.HasSuffix(current, "/") {
 		// Remove last segment for relative resolution
 		if idx := strings.LastIndexByte(current, '/'); idx >= 0 {
@@ -285,7 +440,7 @@ func resolvePath(href string) string {
 		path += "/"
 	}
 
-	return path
+	return path + suffix
 }
 
 func cleanDoubleSlash(s string) string {
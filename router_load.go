@@ -0,0 +1,215 @@
+package preveltekit
+
+import "strings"
+
+// RouteContext describes the navigation a Route's Guard or Load runs for.
+type RouteContext struct {
+	Path   string            // matched path, without query string and fragment
+	Params map[string]string // named segments captured from the route pattern
+	Query  map[string]string // decoded query string
+}
+
+// maxRedirects bounds redirects (Redirect routes and guards) that lead to
+// more redirects.
+const maxRedirects = 10
+
+// Data returns a store with the result of the current route's Load, or nil
+// when the route has no Load. Read it with RouteData in the route component.
+func (r *Router) Data() *Store[any] {
+	return r.data
+}
+
+// Loading returns a store that is true while the guards and loader of a
+// navigation are running.
+func (r *Router) Loading() *Store[bool] {
+	return r.loading
+}
+
+// LoadError returns a store with the error message of the current route's
+// Load ("" when it succeeded).
+func (r *Router) LoadError() *Store[string] {
+	return r.loadError
+}
+
+// RouteData returns the current route's loaded data as a T, or the zero T
+// if the route has no Load, it failed, or its result is not a T. Call it in
+// Render: the route component renders again whenever its Load produced new
+// data, also between two paths of the same route. Expect the zero T, since
+// SSR renders every route component, not just the current one.
+//
+// Example:
+//
+//	routes := []p.Route{{
+//	    Path: "/post/:id", Component: postPage, Pending: &Spinner{},
+//	    Load: func(rc p.RouteContext) (any, error) {
+//	        return p.Get[*Post]("/api/posts/" + rc.Params["id"])
+//	    },
+//	}}
+//
+//	func (pg *PostPage) Render() p.Node {
+//	    post := p.RouteData[*Post](p.CurrentRouter())
+//	    if post == nil {
+//	        return p.Article() // another route is current
+//	    }
+//	    return p.Article(p.H1(post.Title), p.P(post.Body))
+//	}
+func RouteData[T any](r *Router) T {
+	if r == nil {
+		var zero T
+		return zero
+	}
+	v, _ := r.data.Get().(T)
+	return v
+}
+
+// CurrentRouter returns the router whose routes the calling component is
+// rendered in, or nil outside a router. Call it in Render.
+func CurrentRouter() *Router {
+	return Inject[outletLevel](outletKey{}).router
+}
+
+// async reports whether the route has to resolve before it can be shown:
+// it, or one of its layouts, has a Guard, or it has a Load.
+func (e *routeEntry) async() bool {
+	if e.route().Load != nil {
+		return true
+	}
+	for _, route := range e.chain {
+		if route.Guard != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve runs the guards of the chain, outermost first, then the route's
+// Load. A guard's redirect stops the resolution.
+func (e *routeEntry) resolve(rc RouteContext) (redirect string, data any, err error) {
+	for _, route := range e.chain {
+		if route.Guard != nil {
+			if redirect := route.Guard(rc); redirect != "" {
+				return redirect, nil, nil
+			}
+		}
+	}
+	if load := e.route().Load; load != nil {
+		data, err = load(rc)
+	}
+	return "", data, err
+}
+
+// enter shows the matched route. Routes with guards or a loader resolve
+// first, via r.background: in WASM in a goroutine (showing the route's
+// Pending component meanwhile, or keeping the current page), in SSR
+// synchronously, so the page renders with its data. The initial WASM route
+// keeps the pre-rendered page until it has resolved: its layouts hydrate at
+// once, its own outlet stays empty (see showLayouts). A navigation that
+// starts while an earlier one is resolving supersedes it.
+func (r *Router) enter(entry *routeEntry, params map[string]string, path string) {
+	r.navSeq++
+	seq := r.navSeq
+
+	if entry != nil && entry.route().Redirect != "" {
+		// Keep the query string and fragment unless the target sets its own
+		target, query, hash := splitURL(expandRedirect(entry.route().Redirect, params))
+		if query == "" {
+			query = strings.TrimPrefix(encodeQuery(r.query.Values().Get()), "?")
+		}
+		if hash == "" {
+			hash = r.hash.Get()
+		}
+		if query != "" {
+			target += "?" + query
+		}
+		if hash != "" {
+			target += "#" + hash
+		}
+		r.redirect(resolveRoute(r.basePath, target))
+		return
+	}
+
+	if entry == nil || !entry.async() {
+		r.redirects = 0
+		Batch(func() {
+			// Publish params and data before swapping the components so they render with them
+			r.setParams(params)
+			r.data.Set(nil)
+			r.loadError.Set("")
+			r.loading.Set(false)
+			if !r.showRoute(entry, nil, false) && r.notFound != nil {
+				r.notFound()
+			}
+		})
+		return
+	}
+
+	route := entry.route()
+	rc := RouteContext{Path: path, Params: params, Query: r.query.Values().Get()}
+	Batch(func() {
+		r.loading.Set(true)
+		switch {
+		case !r.started:
+			r.setParams(params)
+			r.showLayouts(entry)
+		case route.Pending != nil:
+			r.setParams(params)
+			r.showRoute(entry, route.Pending, false)
+		}
+	})
+
+	r.background(func() {
+		redirect, data, err := entry.resolve(rc)
+		if seq != r.navSeq {
+			return // superseded by a later navigation
+		}
+		if redirect != "" {
+			r.redirect(redirect)
+			return
+		}
+		r.redirects = 0
+
+		Batch(func() {
+			r.setParams(params)
+			r.data.Set(data)
+			shown := Component(nil)
+			if err != nil {
+				r.loadError.Set(err.Error())
+				shown = route.Error
+			} else {
+				r.loadError.Set("")
+			}
+			r.loading.Set(false)
+			if !r.showRoute(entry, shown, true) && r.notFound != nil {
+				r.notFound()
+			}
+		})
+	})
+}
+
+// redirect replaces the current URL with to and handles it, without a new
+// history entry. Chains longer than maxRedirects are stopped.
+func (r *Router) redirect(to string) {
+	r.redirects++
+	if r.redirects > maxRedirects {
+		r.redirects = 0
+		r.loading.Set(false)
+		return
+	}
+	r.replaceURL(to)
+	r.handleRoute(to)
+}
+
+// expandRedirect fills the ":name" segments of a Redirect target with the
+// matched params: "/new/:id" with id=42 → "/new/42".
+func expandRedirect(target string, params map[string]string) string {
+	if !strings.Contains(target, ":") {
+		return target
+	}
+	segs := strings.Split(target, "/")
+	for i, seg := range segs {
+		if len(seg) > 1 && seg[0] == ':' {
+			segs[i] = params[seg[1:]]
+		}
+	}
+	return strings.Join(segs, "/")
+}
@@ -0,0 +1,71 @@
+package preveltekit
+
+import "strings"
+
+// RouterMode selects where the router keeps the route in the URL.
+type RouterMode int
+
+const (
+	// HistoryRouting keeps the route in the path (/user/5) and uses the
+	// History API. Every route needs a pre-rendered page or a server
+	// fallback to index.html (default).
+	HistoryRouting RouterMode = iota
+	// HashRouting keeps the route in the fragment (index.html#/user/5), so
+	// the server only ever serves the pre-rendered pages themselves. Use it
+	// for plain object storage or file:// previews.
+	HashRouting
+)
+
+// routerMode returns the mode passed to NewRouter (HistoryRouting if none).
+func routerMode(mode []RouterMode) RouterMode {
+	if len(mode) > 0 {
+		return mode[0]
+	}
+	return HistoryRouting
+}
+
+// href returns the URL to put into the history for a route URL
+// ("/user/5?tab=2"). In hash mode the route becomes the fragment.
+func (r *Router) href(url string) string {
+	if r.mode == HashRouting {
+		return "#" + url
+	}
+	return url
+}
+
+// hashRoute returns the route URL held in a location fragment: "#/user/5"
+// → "/user/5". An empty fragment is the root route. Any other fragment
+// ("#section") is an anchor within the page, not a route: ok is false.
+func hashRoute(fragment string) (route string, ok bool) {
+	route = strings.TrimPrefix(fragment, "#")
+	if route == "" {
+		return "/", true
+	}
+	if route[0] != '/' {
+		return "", false
+	}
+	return route, true
+}
+
+// prerenderedPath returns the route path the page at pathname was
+// pre-rendered for, found by its HTMLFile among the pages SSR writes
+// (collectSSRPaths, StaticPaths included): "/app/admin/users.html" →
+// "/admin/users". In hash mode the document is one of these pages, while
+// the route in the fragment may be any other. Defaults to "/".
+func (r *Router) prerenderedPath(pathname string) string {
+	file := pathname
+	if file == "" || strings.HasSuffix(file, "/") {
+		file += "index.html"
+	}
+	best, bestLen := "/", 0
+	for _, page := range collectSSRPaths(r.routes) {
+		if page.HTMLFile == "" || page.redirect != "" {
+			continue
+		}
+		html := "/" + strings.TrimPrefix(page.HTMLFile, "/")
+		if strings.HasSuffix(file, html) && len(html) > bestLen {
+			best, bestLen = page.Path, len(html)
+		}
+	}
+	return best
+}
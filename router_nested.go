@@ -0,0 +1,190 @@
+package preveltekit
+
+// routeEntry is one matchable route of the route tree: the route together
+// with its ancestors, and its path pattern joined with theirs.
+type routeEntry struct {
+	pattern string      // Path joined with the ancestors' paths, not yet resolved against the base path
+	chain   []*Route    // ancestors and the route itself, outermost first
+	comps   []Component // the chain's components, one per outlet depth
+}
+
+// route returns the matched (innermost) route of the entry.
+func (e *routeEntry) route() *Route {
+	return e.chain[len(e.chain)-1]
+}
+
+// flattenRoutes lists every route of the tree. A child's Path is relative to
+// its parent's; absolute child paths stay as they are. Children are listed
+// before their parent, so an index child (Path "") wins the tie with it.
+func flattenRoutes(routes []Route, parentPattern string, parents []*Route) []routeEntry {
+	var entries []routeEntry
+	for i := range routes {
+		route := &routes[i]
+		pattern := route.Path
+		if len(parents) > 0 {
+			pattern = resolveRoute(parentPattern, route.Path)
+		}
+		chain := append(parents[:len(parents):len(parents)], route)
+		entries = append(entries, flattenRoutes(route.Children, pattern, chain)...)
+
+		var comps []Component
+		for _, r := range chain {
+			if r.Component != nil {
+				comps = append(comps, r.Component)
+			}
+		}
+		entries = append(entries, routeEntry{pattern: pattern, chain: chain, comps: comps})
+	}
+	return entries
+}
+
+// outletEmpty fills an outlet whose layout matched without a child route.
+type outletEmpty struct{}
+
+func (o *outletEmpty) Render() Node { return Fragment() }
+
+// newOutlets creates the component stores of the route tree. outlets[0] is
+// the router's store for the top-level routes; outlets[d] holds the routes
+// d layouts deep and is shown by the Outlet() of the layout above them.
+// Every route component is registered as an option of its store, so SSR
+// pre-renders all branches.
+func newOutlets(top *Store[Component], routes []Route, id string, empty Component) []*Store[Component] {
+	outlets := []*Store[Component]{top}
+	var register func(routes []Route, depth int)
+	register = func(routes []Route, depth int) {
+		for _, route := range routes {
+			childDepth := depth
+			if route.Component != nil {
+				for len(outlets) <= depth {
+					s := newWithID(id+".outlet"+itoa(len(outlets)), empty)
+					s.WithOptions(empty)
+					outlets = append(outlets, s)
+				}
+				outlets[depth].WithOptions(route.Component)
+				for _, state := range []Component{route.Pending, route.Error} {
+					if state != nil {
+						outlets[depth].WithOptions(state)
+					}
+				}
+				childDepth++
+			}
+			register(route.Children, childDepth)
+		}
+	}
+	register(routes, 0)
+	return outlets
+}
+
+// matchPath finds the most specific route for path. Each pattern is
+// resolved against the base path before matching; ties go to the route
+// listed first.
+func (r *Router) matchPath(path string) (*routeEntry, map[string]string) {
+	var best *routeEntry
+	var bestParams map[string]string
+	bestSpecificity := -1
+
+	for i := range r.entries {
+		entry := &r.entries[i]
+		resolved := resolveRoute(r.basePath, entry.pattern)
+		params, specificity, ok := matchRoute(resolved, path)
+		if ok && specificity > bestSpecificity {
+			best = entry
+			bestParams = params
+			bestSpecificity = specificity
+		}
+	}
+	return best, bestParams
+}
+
+// showRoute sets every outlet to its component along the matched chain, in
+// one batch. A layout that stays the same keeps its DOM; only the outlets
+// below it swap. A non-nil leaf (the route's Pending or Error component) is
+// shown in place of the route's own component. With reload, the leaf renders
+// again even if it is already shown, so it reads the newly loaded data
+// (/post/7 → /post/8). Reports false if the route has no component to show.
+func (r *Router) showRoute(entry *routeEntry, leaf Component, reload bool) bool {
+	if entry == nil || entry.route().Component == nil {
+		return false
+	}
+	Batch(func() {
+		for d, s := range r.outlets {
+			if d < len(entry.comps) {
+				if d < len(entry.comps)-1 {
+					s.Set(entry.comps[d])
+					continue
+				}
+				if leaf == nil {
+					leaf = entry.comps[d]
+				}
+				s.Set(leaf)
+				if reload {
+					s.remount()
+				}
+			} else if d > 0 {
+				s.Set(r.emptyOutlet)
+			}
+		}
+	})
+	return true
+}
+
+// showLayouts sets the outlets of the matched chain's layouts and empties the
+// route's own outlet, for the initial route while it resolves. The layouts
+// hydrate right away; an outlet that is nil when it is hydrated keeps its
+// pre-rendered DOM until showRoute fills it.
+func (r *Router) showLayouts(entry *routeEntry) {
+	last := len(entry.comps) - 1
+	Batch(func() {
+		for d := 0; d < last && d < len(r.outlets); d++ {
+			r.outlets[d].Set(entry.comps[d])
+		}
+		if last >= 0 && last < len(r.outlets) {
+			r.outlets[last].Set(nil)
+		}
+	})
+}
+
+// outletKey is the Provide key under which each layout finds its outlet.
+type outletKey struct{}
+
+// outletLevel is the outlet the next Outlet() call renders.
+type outletLevel struct {
+	router *Router
+	depth  int
+}
+
+// OutletNode renders the child route of a layout component.
+type OutletNode struct {
+	Store *Store[Component] // outlet store of the layout's depth
+}
+
+func (o *OutletNode) nodeType() string { return "outlet" }
+
+// Outlet marks where a layout route's component shows its matched child
+// route. Call it in the layout's Render. When navigating between children
+// of the same layout, the layout stays mounted and only the outlet swaps.
+//
+// Example:
+//
+//	routes := []p.Route{
+//	    {Path: "/admin", Component: adminLayout, Children: []p.Route{
+//	        {Path: "", HTMLFile: "admin.html", SSRPath: "/admin", Component: dashboard},
+//	        {Path: "users", HTMLFile: "admin/users.html", SSRPath: "/admin/users", Component: users},
+//	    }},
+//	}
+//
+//	func (a *AdminLayout) Render() p.Node {
+//	    return p.Div(
+//	        p.Nav(p.A(p.Attr("href", "/admin"), "Dashboard"), p.A(p.Attr("href", "/admin/users"), "Users")),
+//	        p.Main(p.Outlet()),
+//	    )
+//	}
+func Outlet() Node {
+	level := Inject[outletLevel](outletKey{})
+	if level.router == nil || level.depth >= len(level.router.outlets) {
+		return Fragment()
+	}
+	// Layouts rendered in this outlet get the next one
+	Provide(outletKey{}, outletLevel{router: level.router, depth: level.depth + 1})
+	return &OutletNode{Store: level.router.outlets[level.depth]}
+}
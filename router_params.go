@@ -0,0 +1,45 @@
+package preveltekit
+
+// Params returns a store containing the named segments captured from the
+// matched route (e.g., {"id": "42"} for pattern "/user/:id" and path "/user/42").
+// Updated on every navigation, and during SSR from the path set via SetSSRPath.
+func (r *Router) Params() *Store[map[string]string] {
+	return r.params
+}
+
+// Param returns a reactive store for a single route parameter.
+// The store holds "" when the current route has no such parameter.
+// Stores are created once per key with the ID "{routerID}.param.{key}".
+//
+// Example:
+//
+//	p.P("User: ", router.Param("id"))
+func (r *Router) Param(key string) *Store[string] {
+	if s, ok := r.paramStores[key]; ok {
+		return s
+	}
+	if r.paramStores == nil {
+		r.paramStores = make(map[string]*Store[string])
+	}
+	s := newWithID(r.id+".param."+key, r.params.Get()[key])
+	r.paramStores[key] = s
+	return s
+}
+
+// ParamInt returns the current value of a route parameter parsed as an int.
+// Returns 0 if the parameter is missing or not numeric.
+func (r *Router) ParamInt(key string) int {
+	return atoiSafe(r.params.Get()[key])
+}
+
+// setParams publishes the params of the matched route.
+// A nil map (no match) clears all parameters.
+func (r *Router) setParams(params map[string]string) {
+	if params == nil {
+		params = map[string]string{}
+	}
+	r.params.Set(params)
+	for key, s := range r.paramStores {
+		s.Set(params[key])
+	}
+}
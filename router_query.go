@@ -0,0 +1,248 @@
+package preveltekit
+
+import "strings"
+
+// Query is the reactive query string of the router's current URL. It is
+// updated on every navigation; the setters write it back to the URL
+// (history.pushState, or replaceState for Replace) without re-matching the
+// route, so search and filter state stays shareable.
+//
+// Example:
+//
+//	q := router.Query()
+//	p.Input(p.Attr("type", "search")).Bind(q.Value("q"))  // live, reflects back/forward
+//	q.Value("q").OnChange(func(s string) { q.Replace("q", s) })
+//	p.Button("Next").On("click", func() { q.SetInt("page", q.Int("page")+1) })
+type Query struct {
+	router *Router
+	values *Store[map[string]string] // decoded key → value (first value of repeated keys)
+	stores map[string]*Store[string] // per-key views, created lazily by Value()
+}
+
+// newQuery creates the query state of router r.
+func newQuery(r *Router) *Query {
+	return &Query{router: r, values: newWithID(r.id+".query", map[string]string{})}
+}
+
+// Query returns the reactive query string of the current URL.
+func (r *Router) Query() *Query {
+	return r.query
+}
+
+// Hash returns a store with the URL fragment, without the leading "#".
+// Setting it updates the URL with history.replaceState.
+func (r *Router) Hash() *Store[string] {
+	return r.hash
+}
+
+// Values returns a store with all query parameters.
+func (q *Query) Values() *Store[map[string]string] {
+	return q.values
+}
+
+// Value returns a reactive store for a single query parameter ("" when
+// absent). Stores are created once per key with the ID
+// "{routerID}.query.{key}". Setting the store does not change the URL; use
+// Set or Replace for that.
+func (q *Query) Value(key string) *Store[string] {
+	if s, ok := q.stores[key]; ok {
+		return s
+	}
+	if q.stores == nil {
+		q.stores = make(map[string]*Store[string])
+	}
+	s := newWithID(q.router.id+".query."+key, q.values.Get()[key])
+	q.stores[key] = s
+	return s
+}
+
+// String returns the current value of a query parameter ("" when absent).
+func (q *Query) String(key string) string {
+	return q.values.Get()[key]
+}
+
+// Int returns a query parameter parsed as an int, 0 if missing or not numeric.
+func (q *Query) Int(key string) int {
+	return atoiSafe(q.values.Get()[key])
+}
+
+// Bool reports whether a query parameter is "1" or "true".
+func (q *Query) Bool(key string) bool {
+	v := q.values.Get()[key]
+	return v == "1" || v == "true"
+}
+
+// Set sets a query parameter and pushes the new URL onto the history.
+// An empty value removes the parameter.
+func (q *Query) Set(key, value string) {
+	q.write(key, value, false)
+}
+
+// SetInt sets a query parameter to an int and pushes the new URL.
+func (q *Query) SetInt(key string, value int) {
+	q.write(key, itoa(value), false)
+}
+
+// SetBool sets a query parameter to "true", or removes it for false, and
+// pushes the new URL.
+func (q *Query) SetBool(key string, value bool) {
+	v := ""
+	if value {
+		v = "true"
+	}
+	q.write(key, v, false)
+}
+
+// Replace sets a query parameter like Set, but replaces the current history
+// entry, e.g. while the user types into a search box.
+func (q *Query) Replace(key, value string) {
+	q.write(key, value, true)
+}
+
+// Delete removes a query parameter and pushes the new URL.
+func (q *Query) Delete(key string) {
+	q.write(key, "", false)
+}
+
+// write updates one parameter and hands the encoded query to the router.
+func (q *Query) write(key, value string, replace bool) {
+	values := make(map[string]string, len(q.values.Get())+1)
+	for k, v := range q.values.Get() {
+		values[k] = v
+	}
+	if value == "" {
+		delete(values, key)
+	} else {
+		values[key] = value
+	}
+	q.router.writeQuery(encodeQuery(values), replace)
+}
+
+// set publishes a decoded query string.
+func (q *Query) set(query string) {
+	values := parseQuery(query)
+	Batch(func() {
+		q.values.Set(values)
+		for key, s := range q.stores {
+			s.Set(values[key])
+		}
+	})
+}
+
+// setURLState publishes the query string and fragment of the current URL.
+func (r *Router) setURLState(query, hash string) {
+	r.query.set(query)
+	r.hash.Set(hash)
+}
+
+// splitURL splits an href into its path, query string and fragment, each
+// without its leading "?" or "#".
+// "/search?q=go#top" → "/search", "q=go", "top".
+func splitURL(href string) (path, query, hash string) {
+	if i := strings.IndexByte(href, '#'); i >= 0 {
+		href, hash = href[:i], href[i+1:]
+	}
+	if i := strings.IndexByte(href, '?'); i >= 0 {
+		href, query = href[:i], href[i+1:]
+	}
+	return href, query, hash
+}
+
+// parseQuery decodes a query string ("a=1&b=x+y") into a map.
+// The first value of a repeated key wins.
+func parseQuery(query string) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		key = queryUnescape(key)
+		if _, ok := values[key]; !ok {
+			values[key] = queryUnescape(value)
+		}
+	}
+	return values
+}
+
+// encodeQuery encodes values as "?a=1&b=x+y" with the keys sorted, or ""
+// when values is empty.
+func encodeQuery(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		// insertion sort: query strings have a handful of keys
+		i := len(keys)
+		keys = append(keys, k)
+		for ; i > 0 && keys[i-1] > k; i-- {
+			keys[i] = keys[i-1]
+		}
+		keys[i] = k
+	}
+	var sb strings.Builder
+	for i, k := range keys {
+		if i == 0 {
+			sb.WriteByte('?')
+		} else {
+			sb.WriteByte('&')
+		}
+		sb.WriteString(queryEscape(k))
+		sb.WriteByte('=')
+		sb.WriteString(queryEscape(values[k]))
+	}
+	return sb.String()
+}
+
+// queryEscape percent-encodes s for a query string; spaces become "+".
+func queryEscape(s string) string {
+	const hex = "0123456789ABCDEF"
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			sb.WriteByte(c)
+		case c == ' ':
+			sb.WriteByte('+')
+		default:
+			sb.WriteByte('%')
+			sb.WriteByte(hex[c>>4])
+			sb.WriteByte(hex[c&15])
+		}
+	}
+	return sb.String()
+}
+
+// queryUnescape decodes "+" and %XX escapes. Malformed escapes are kept as-is.
+func queryUnescape(s string) string {
+	if !strings.ContainsAny(s, "+%") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '+':
+			sb.WriteByte(' ')
+		case c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			sb.WriteByte(unhex(s[i+1])<<4 | unhex(s[i+2]))
+			i += 2
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case c >= 'a':
+		return c - 'a' + 10
+	case c >= 'A':
+		return c - 'A' + 10
+	}
+	return c - '0'
+}
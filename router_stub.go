@@ -2,33 +2,58 @@
 
 package preveltekit
 
+import "strings"
+
 // Router handles client-side routing
 type Router struct {
 	componentStore *Store[Component]
 	routes         []Route
+	entries        []routeEntry
+	outlets        []*Store[Component]
+	emptyOutlet    Component
 	id             string
+	mode           RouterMode
 	basePath       string
 	notFound       func()
 	currentPath    *Store[string]
+	params         *Store[map[string]string]
+	paramStores    map[string]*Store[string]
+	query          *Query
+	hash           *Store[string]
+	data           *Store[any]
+	loading        *Store[bool]
+	loadError      *Store[string]
+	navSeq         int
+	redirects      int
+	started        bool
 	beforeNav      func(from, to string) bool
 }
 
 // NewRouter creates a new router instance and registers the ID for SSR.
 // Automatically registers all route components as options on the component store
-// so SSR can pre-render all branches.
-func NewRouter(componentStore *Store[Component], routes []Route, id string) *Router {
-	// Register all route components as store options for pre-baked rendering
-	for _, route := range routes {
-		if route.Component != nil {
-			componentStore.WithOptions(route.Component)
-		}
-	}
-	return &Router{
+// so SSR can pre-render all branches. In HashRouting mode SSR still renders
+// each SSRPath; the browser picks the route from the fragment.
+func NewRouter(componentStore *Store[Component], routes []Route, id string, mode ...RouterMode) *Router {
+	r := &Router{
 		componentStore: componentStore,
 		routes:         routes,
+		entries:        flattenRoutes(routes, "", nil),
+		emptyOutlet:    &outletEmpty{},
 		id:             id,
+		mode:           routerMode(mode),
 		currentPath:    newWithID(id+".path", ""),
+		params:         newWithID(id+".params", map[string]string{}),
+		hash:           newWithID(id+".hash", ""),
+		data:           newWithID[any](id+".data", nil),
+		loading:        newWithID(id+".loading", false),
+		loadError:      newWithID(id+".loadError", ""),
 	}
+	r.query = newQuery(r)
+	// Register all route components as options of their outlet store for
+	// pre-baked rendering, and let the top-level layouts find the first outlet
+	r.outlets = newOutlets(componentStore, routes, id, r.emptyOutlet)
+	Provide(outletKey{}, outletLevel{router: r, depth: 1})
+	return r
 }
 
 // NotFound sets the handler for unmatched routes
@@ -55,12 +80,15 @@ func (r *Router) Start() {
 	}
 	// In SSR, the base path is always "/" since SSRPaths are root-relative
 	r.basePath = "/"
-	r.currentPath.Set(path)
 	r.handleRoute(path)
 }
 
-// handleRoute matches the path and sets the component
+// handleRoute matches the path and sets the component.
+// A query string or fragment in path is published to Query() and Hash().
 func (r *Router) handleRoute(path string) {
+	path, query, hash := splitURL(path)
+	r.setURLState(query, hash)
+
 	// Normalize path
 	if path == "" {
 		path = "/"
@@ -69,26 +97,13 @@ func (r *Router) handleRoute(path string) {
 		path = path[:len(path)-1]
 	}
 
+	r.currentPath.Set(path)
+
 	// Find matching route (most specific first)
-	// Each route's Path is resolved against the base path before matching.
-	var bestMatch *Route
-	bestSpecificity := -1
-
-	for i := range r.routes {
-		route := &r.routes[i]
-		resolved := resolveRoute(r.basePath, route.Path)
-		_, specificity, ok := matchRoute(resolved, path)
-		if ok && specificity > bestSpecificity {
-			bestMatch = route
-			bestSpecificity = specificity
-		}
-	}
+	entry, params := r.matchPath(path)
 
-	if bestMatch != nil && bestMatch.Component != nil {
-		r.componentStore.Set(bestMatch.Component)
-	} else if r.notFound != nil {
-		r.notFound()
-	}
+	// Run guards and loader, then show the route
+	r.enter(entry, params, path)
 }
 
 // SetupLinks intercepts link clicks (no-op for SSR)
@@ -99,3 +114,17 @@ func (r *Router) Navigate(path string) {}
 
 // Replace navigates without adding to history (no-op for SSR)
 func (r *Router) Replace(path string) {}
+
+// background runs a navigation's guards and loader synchronously, so the
+// loaded data is baked into the pre-rendered page.
+func (r *Router) background(fn func()) {
+	fn()
+}
+
+// replaceURL has no URL to replace in SSR; a redirect renders the target route.
+func (r *Router) replaceURL(url string) {}
+
+// writeQuery publishes a query string set through Query() (SSR has no URL to update)
+func (r *Router) writeQuery(query string, replace bool) {
+	r.setURLState(strings.TrimPrefix(query, "?"), r.hash.Get())
+}
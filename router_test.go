@@ -0,0 +1,323 @@
+//go:build !wasm
+
+package preveltekit
+
+import (
+	"strings"
+	"testing"
+)
+
+type routerTestPage struct{ name string }
+
+func (p *routerTestPage) Render() Node { return Div(p.name) }
+
+func TestRouterParams(t *testing.T) {
+	home := &routerTestPage{"home"}
+	user := &routerTestPage{"user"}
+	routes := []Route{
+		{Path: "/", HTMLFile: "index.html", SSRPath: "/", Component: home},
+		{Path: "/user/:id", HTMLFile: "user.html", SSRPath: "/user/42", Component: user},
+	}
+
+	SetSSRPath("/user/42")
+	defer SetSSRPath("")
+
+	store := New[Component](nil)
+	router := NewRouter(store, routes, "test")
+	id := router.Param("id")
+	router.Start()
+
+	if store.Get() != user {
+		t.Fatalf("component = %v, want user page", store.Get())
+	}
+	if got := router.Params().Get()["id"]; got != "42" {
+		t.Errorf("Params()[id] = %q, want %q", got, "42")
+	}
+	if got := id.Get(); got != "42" {
+		t.Errorf("Param(id) = %q, want %q", got, "42")
+	}
+	if got := router.ParamInt("id"); got != 42 {
+		t.Errorf("ParamInt(id) = %d, want 42", got)
+	}
+
+	router.handleRoute("/")
+	if got := id.Get(); got != "" {
+		t.Errorf("Param(id) after leaving route = %q, want empty", got)
+	}
+	if len(router.Params().Get()) != 0 {
+		t.Errorf("Params() after leaving route = %v, want empty", router.Params().Get())
+	}
+}
+
+type routerTestLayout struct {
+	name   string
+	mounts int
+}
+
+func (l *routerTestLayout) OnMount() { l.mounts++ }
+
+func (l *routerTestLayout) Render() Node {
+	return Div(Attr("class", l.name), Outlet())
+}
+
+func TestNestedRoutes(t *testing.T) {
+	resetRegistries()
+	home := &routerTestPage{"home"}
+	admin := &routerTestLayout{name: "admin"}
+	dashboard := &routerTestPage{"dashboard"}
+	settings := &routerTestLayout{name: "settings"}
+	profile := &routerTestPage{"profile"}
+	routes := []Route{
+		{Path: "/", HTMLFile: "index.html", SSRPath: "/", Component: home},
+		{Path: "/admin", Component: admin, Children: []Route{
+			{Path: "", HTMLFile: "admin.html", SSRPath: "/admin", Component: dashboard},
+			{Path: "settings", Component: settings, Children: []Route{
+				{Path: ":tab", HTMLFile: "admin/settings.html", SSRPath: "/admin/settings/profile", Component: profile},
+			}},
+		}},
+	}
+
+	pages := collectSSRPaths(routes)
+	if len(pages) != 3 || pages[1].Path != "/admin" || pages[2].Path != "/admin/settings/profile" {
+		t.Errorf("collectSSRPaths = %v", pages)
+	}
+
+	SetSSRPath("/admin/settings/profile")
+	defer SetSSRPath("")
+
+	store := New[Component](nil)
+	router := NewRouter(store, routes, "nested")
+	router.Start()
+
+	if store.Get() != admin || router.outlets[1].Get() != settings || router.outlets[2].Get() != profile {
+		t.Fatalf("outlets = %v, %v, %v", store.Get(), router.outlets[1].Get(), router.outlets[2].Get())
+	}
+	if got := router.Params().Get()["tab"]; got != "profile" {
+		t.Errorf("Params()[tab] = %q, want %q", got, "profile")
+	}
+
+	html := nodeToHTML(Div(store), NewBuildContext())
+	for _, want := range []string{`<div class="admin">`, `<div class="settings">`, `<div>profile</div>`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("rendered page lacks %s:\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "dashboard") || strings.Contains(html, "home") {
+		t.Errorf("rendered page shows an inactive route:\n%s", html)
+	}
+	if admin.mounts != 1 || settings.mounts != 1 {
+		t.Errorf("OnMount calls: admin %d, settings %d, want 1 each", admin.mounts, settings.mounts)
+	}
+
+	router.handleRoute("/admin")
+	if store.Get() != admin || router.outlets[1].Get() != dashboard || router.outlets[2].Get() != router.emptyOutlet {
+		t.Errorf("/admin outlets = %v, %v, %v", store.Get(), router.outlets[1].Get(), router.outlets[2].Get())
+	}
+
+	router.handleRoute("/")
+	if store.Get() != home || router.outlets[1].Get() != router.emptyOutlet {
+		t.Errorf("/ outlets = %v, %v", store.Get(), router.outlets[1].Get())
+	}
+}
+
+func TestRouterQuery(t *testing.T) {
+	resetRegistries()
+	search := &routerTestPage{"search"}
+	routes := []Route{{Path: "/search", HTMLFile: "search.html", SSRPath: "/search", Component: search}}
+
+	SetSSRPath("/search?q=go+wasm&page=2&tag=a%26b&q=ignored#results")
+	defer SetSSRPath("")
+
+	store := New[Component](nil)
+	router := NewRouter(store, routes, "query")
+	q := router.Query()
+	term := q.Value("q")
+	router.Start()
+
+	if store.Get() != search || router.CurrentPath().Get() != "/search" {
+		t.Fatalf("route = %v at %q, want search page at /search", store.Get(), router.CurrentPath().Get())
+	}
+	if term.Get() != "go wasm" || q.Int("page") != 2 || q.String("tag") != "a&b" {
+		t.Errorf("query = %v", q.Values().Get())
+	}
+	if got := router.Hash().Get(); got != "results" {
+		t.Errorf("Hash() = %q, want %q", got, "results")
+	}
+
+	q.SetInt("page", 3)
+	q.SetBool("exact", true)
+	q.Replace("q", "")
+	if term.Get() != "" || q.Int("page") != 3 || !q.Bool("exact") {
+		t.Errorf("query after setters = %v", q.Values().Get())
+	}
+	if got := encodeQuery(q.Values().Get()); got != "?exact=true&page=3&tag=a%26b" {
+		t.Errorf("encodeQuery = %q", got)
+	}
+
+	router.handleRoute("/search")
+	if len(q.Values().Get()) != 0 || router.Hash().Get() != "" {
+		t.Errorf("query %v and hash %q not cleared", q.Values().Get(), router.Hash().Get())
+	}
+}
+
+type routerTestPost struct{ title string }
+
+type routerTestPostPage struct{}
+
+func (p *routerTestPostPage) Render() Node {
+	post := RouteData[*routerTestPost](CurrentRouter())
+	if post == nil {
+		return Div("no post")
+	}
+	return Div(post.title)
+}
+
+func TestRouteLoadAndGuard(t *testing.T) {
+	resetRegistries()
+	home := &routerTestPage{"home"}
+	login := &routerTestPage{"login"}
+	post := &routerTestPostPage{}
+	failed := &routerTestPage{"failed"}
+	loggedIn := false
+	var loadedFor []string
+	routes := []Route{
+		{Path: "/", Component: home},
+		{Path: "/login", Component: login},
+		{Path: "/post/:id", Component: post, Error: failed, Pending: &routerTestPage{"loading"},
+			Load: func(rc RouteContext) (any, error) {
+				loadedFor = append(loadedFor, rc.Params["id"]+"?"+rc.Query["v"])
+				if rc.Params["id"] == "0" {
+					return nil, &FetchError{Status: 404}
+				}
+				return &routerTestPost{"Post " + rc.Params["id"]}, nil
+			}},
+		{Path: "/admin", Guard: func(rc RouteContext) string {
+			if !loggedIn {
+				return "/login"
+			}
+			return ""
+		}, Children: []Route{{Path: "", Component: &routerTestPage{"admin"}}}},
+	}
+
+	store := New[Component](nil)
+	router := NewRouter(store, routes, "load")
+	router.Start()
+	router.handleRoute("/post/7?v=2")
+
+	if store.Get() != post || len(loadedFor) != 1 || loadedFor[0] != "7?2" {
+		t.Fatalf("component %v, loads %v", store.Get(), loadedFor)
+	}
+	html := nodeToHTML(Div(store), NewBuildContext())
+	if !strings.Contains(html, "<div>Post 7</div>") || strings.Contains(html, "loading") {
+		t.Errorf("loaded data not rendered:\n%s", html)
+	}
+	if router.Loading().Get() || router.LoadError().Get() != "" {
+		t.Errorf("loading %v, error %q after a successful load", router.Loading().Get(), router.LoadError().Get())
+	}
+
+	router.handleRoute("/post/0")
+	if store.Get() != failed || router.LoadError().Get() == "" || router.Data().Get() != nil {
+		t.Errorf("failed load shows %v with error %q and data %v", store.Get(), router.LoadError().Get(), router.Data().Get())
+	}
+
+	router.handleRoute("/admin")
+	if store.Get() != login || router.CurrentPath().Get() != "/login" {
+		t.Errorf("guard did not redirect: %v at %q", store.Get(), router.CurrentPath().Get())
+	}
+	loggedIn = true
+	router.handleRoute("/admin")
+	if router.CurrentPath().Get() != "/admin" || router.outlets[0].Get() == login {
+		t.Errorf("guard blocked an allowed navigation: %v at %q", store.Get(), router.CurrentPath().Get())
+	}
+
+	router.handleRoute("/")
+	if store.Get() != home || router.Data().Get() != nil {
+		t.Errorf("data %v kept after leaving the route", router.Data().Get())
+	}
+}
+
+func TestRouterRedirect(t *testing.T) {
+	resetRegistries()
+	page := &routerTestPage{"new"}
+	routes := []Route{
+		{Path: "/new/:id", Component: page},
+		{Path: "/old/:id", Redirect: "/new/:id"},
+		{Path: "/loop", Redirect: "/loop"},
+	}
+	store := New[Component](nil)
+	router := NewRouter(store, routes, "redirect")
+	router.Start()
+
+	router.handleRoute("/old/42?tab=2#notes")
+	if store.Get() != page || router.CurrentPath().Get() != "/new/42" || router.Param("id").Get() != "42" {
+		t.Errorf("redirect shows %v at %q", store.Get(), router.CurrentPath().Get())
+	}
+	if tab, hash := router.Query().String("tab"), router.Hash().Get(); tab != "2" || hash != "notes" {
+		t.Errorf("query or fragment not kept across the redirect: tab = %q, hash = %q", tab, hash)
+	}
+
+	router.handleRoute("/loop") // must stop after maxRedirects
+	router.handleRoute("/old/7")
+	if router.CurrentPath().Get() != "/new/7" {
+		t.Errorf("redirect after a stopped loop ends at %q", router.CurrentPath().Get())
+	}
+}
+
+func TestHashRouting(t *testing.T) {
+	resetRegistries()
+	home := &routerTestPage{"home"}
+	users := &routerTestPage{"users"}
+	routes := []Route{
+		{Path: "/", HTMLFile: "index.html", SSRPath: "/", Component: home},
+		{Path: "/admin/users", HTMLFile: "admin/users.html", SSRPath: "/admin/users", Component: users},
+		{Path: "/blog/:slug", Component: home, StaticPaths: func() []StaticPath {
+			return []StaticPath{{Path: "/blog/hello"}, {Path: "/blog/bye", HTMLFile: "posts/bye.html"}}
+		}},
+	}
+	store := New[Component](nil)
+	router := NewRouter(store, routes, "hash", HashRouting)
+
+	for fragment, want := range map[string]string{
+		"":             "/",
+		"#":            "/",
+		"#/user/5":     "/user/5",
+		"#/a?q=go#top": "/a?q=go#top",
+		"#section":     "", // an anchor, not a route
+		"#user/5?x=1":  "",
+	} {
+		got, ok := hashRoute(fragment)
+		if got != want || ok != (want != "") {
+			t.Errorf("hashRoute(%q) = %q, %v, want %q", fragment, got, ok, want)
+		}
+	}
+
+	for pathname, want := range map[string]string{
+		"/":                              "/",
+		"/app/":                          "/",
+		"/app/index.html":                "/",
+		"/app/admin/users.html":          "/admin/users",
+		"/admin/users.html":              "/admin/users",
+		"/app/unknown.html":              "/",
+		"/home/me/site/index.html":       "/",
+		"/home/me/site/admin/users.html": "/admin/users",
+		"/app/blog/hello.html":           "/blog/hello",
+		"/app/posts/bye.html":            "/blog/bye",
+	} {
+		if got := router.prerenderedPath(pathname); got != want {
+			t.Errorf("prerenderedPath(%q) = %q, want %q", pathname, got, want)
+		}
+	}
+
+	if got := router.href("/admin/users?tab=2"); got != "#/admin/users?tab=2" {
+		t.Errorf("hash mode href = %q", got)
+	}
+	if got := NewRouter(New[Component](nil), routes, "history").href("/admin/users"); got != "/admin/users" {
+		t.Errorf("history mode href = %q", got)
+	}
+
+	router.Start()
+	router.handleRoute("/admin/users")
+	if store.Get() != users || router.CurrentPath().Get() != "/admin/users" {
+		t.Errorf("hash mode shows %v at %q", store.Get(), router.CurrentPath().Get())
+	}
+}
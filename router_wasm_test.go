@@ -0,0 +1,179 @@
+//go:build wasm
+
+package preveltekit
+
+import (
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+type routerWasmPostPage struct{}
+
+func (p *routerWasmPostPage) Render() Node { return Div(RouteData[string](CurrentRouter())) }
+
+// startRouter starts r at url in the test DOM and removes its window
+// listeners when the test ends.
+func startRouter(t *testing.T, r *Router, url string) {
+	t.Helper()
+	installDOM(t)
+	js.Global().Call("__setLocation", url)
+	r.Start()
+	t.Cleanup(func() {
+		js.Global().Call("removeEventListener", "popstate", r.popstateFn)
+		js.Global().Call("removeEventListener", "click", r.clickFn)
+	})
+}
+
+// prerender returns page as SSR renders it with comp shown and data loaded,
+// then clears the router's state again for hydration.
+func prerender(r *Router, page Node, comp Component, data any) string {
+	r.data.Set(data)
+	r.componentStore.Set(comp)
+	html := wasmNodeToHTML(page, &WASMRenderContext{})
+	wasmRenderedTrees = make(map[string][]wasmCachedOption)
+	r.data.Set(nil)
+	r.componentStore.Set(nil)
+	return html
+}
+
+func TestRouteInitialLoad(t *testing.T) {
+	resetRegistries()
+	loads := 0
+	release := make(chan struct{})
+	routes := []Route{{Path: "/post/:id", Component: &routerWasmPostPage{}, Load: func(rc RouteContext) (any, error) {
+		loads++
+		select {
+		case <-release:
+		case <-time.After(time.Second):
+			t.Error("hydration waited for Load")
+		}
+		return "Post " + rc.Params["id"], nil
+	}}}
+	store := New[Component](nil)
+	router := NewRouter(store, routes, "initial")
+	page := Div(store)
+
+	ssr := prerender(router, page, routes[0].Component, "Post 7")
+
+	// Hydrate: the router starts in OnMount, before the tree is wired.
+	// Neither waits for Load, and the pre-rendered page stays meanwhile.
+	startRouter(t, router, "/post/7")
+	body := hydrateDOM(t, page, ssr)
+	if got := text(body); got != "Post 7" {
+		t.Errorf("while loading: text = %q, want the pre-rendered Post 7", got)
+	}
+
+	close(release)
+	waitFor(t, "the initial load", func() bool { return !router.Loading().Get() })
+	if got := text(body); got != "Post 7" {
+		t.Errorf("after loading: text = %q, want Post 7", got)
+	}
+	if loads != 1 {
+		t.Errorf("Load ran %d times, want 1", loads)
+	}
+}
+
+func TestRouteReloadSameRoute(t *testing.T) {
+	resetRegistries()
+	routes := []Route{
+		{Path: "/", Component: &outletEmpty{}},
+		{Path: "/post/:id", Component: &routerWasmPostPage{}, Load: func(rc RouteContext) (any, error) {
+			return "Post " + rc.Params["id"], nil
+		}},
+	}
+	store := New[Component](nil)
+	router := NewRouter(store, routes, "reload")
+	page := Div(store)
+	ssr := prerender(router, page, routes[1].Component, "Post 7")
+	startRouter(t, router, "/post/7")
+	body := hydrateDOM(t, page, ssr)
+	waitFor(t, "the initial load", func() bool { return !router.Loading().Get() })
+	if got := text(body); got != "Post 7" {
+		t.Fatalf("after the initial load: text = %q, want Post 7", got)
+	}
+
+	// Same route, new data: the block renders the shown component again
+	router.Navigate("/post/8")
+	waitFor(t, "Post 8", func() bool { return text(body) == "Post 8" })
+}
+
+func TestRouteFragmentOnly(t *testing.T) {
+	resetRegistries()
+	loads := 0
+	routes := []Route{{Path: "/post/:id", Component: &routerWasmPostPage{}, Load: func(rc RouteContext) (any, error) {
+		loads++
+		return "Post " + rc.Params["id"], nil
+	}}}
+	router := NewRouter(New[Component](nil), routes, "fragment")
+	startRouter(t, router, "/post/7?tab=2")
+	waitFor(t, "the initial load", func() bool { return !router.Loading().Get() })
+
+	router.handleRoute("/post/7?tab=2#comments") // popstate of an in-page anchor
+	if loads != 1 || router.Loading().Get() {
+		t.Errorf("fragment change re-entered the route: %d loads, loading %v", loads, router.Loading().Get())
+	}
+	if router.Hash().Get() != "comments" || router.Query().String("tab") != "2" {
+		t.Errorf("hash %q, tab %q after a fragment change", router.Hash().Get(), router.Query().String("tab"))
+	}
+}
+
+func TestHashRoutingAnchor(t *testing.T) {
+	resetRegistries()
+	routes := []Route{
+		{Path: "/", HTMLFile: "index.html", SSRPath: "/", Component: &outletEmpty{}},
+		{Path: "/section", Component: &outletEmpty{}},
+		{Path: "/blog/:slug", Component: &routerWasmPostPage{}, StaticPaths: func() []StaticPath {
+			return []StaticPath{{Path: "/blog/hello"}}
+		}},
+	}
+	router := NewRouter(New[Component](nil), routes, "anchor", HashRouting)
+
+	// A StaticPaths page opened with an anchor keeps its route
+	startRouter(t, router, "/app/blog/hello.html#section")
+	waitFor(t, "the anchor", func() bool { return router.Hash().Get() == "section" })
+	if got := router.CurrentPath().Get(); got != "/blog/hello" {
+		t.Errorf("route = %q, want /blog/hello", got)
+	}
+
+	// A fragment starting with #/ is a route
+	js.Global().Get("location").Set("hash", "#/section")
+	js.Global().Call("dispatchEvent", js.Global().Get("Event").New("popstate"))
+	if got := router.CurrentPath().Get(); got != "/section" {
+		t.Errorf("route = %q after #/section, want /section", got)
+	}
+}
+
+func TestLinkClickStopped(t *testing.T) {
+	resetRegistries()
+	installDOM(t)
+	js.Global().Call("__setLocation", "/")
+	router := NewRouter(New[Component](nil), []Route{
+		{Path: "/stopped", Component: &outletEmpty{}},
+		{Path: "/plain", Component: &outletEmpty{}},
+	}, "links")
+	// Set up before the delegated click handler, so listener order on
+	// document would let the router see the click first
+	router.SetupLinks()
+	t.Cleanup(func() { js.Global().Call("removeEventListener", "click", router.clickFn) })
+
+	clicks := 0
+	body := mount(t, Div(
+		A(Attr("href", "/stopped"), Span("stop").On("click", func() { clicks++ }).StopPropagation()),
+		A(Attr("href", "/plain"), Span("go")),
+	))
+
+	spans := body.Call("querySelectorAll", "span")
+	spans.Index(0).Call("click")
+	if clicks != 1 {
+		t.Fatalf("handler ran %d times", clicks)
+	}
+	if path := js.Global().Get("location").Get("pathname").String(); path != "/" {
+		t.Errorf("stopped click navigated to %s", path)
+	}
+
+	spans.Index(1).Call("click")
+	if path := js.Global().Get("location").Get("pathname").String(); path != "/plain" {
+		t.Errorf("link click navigated to %s, want /plain", path)
+	}
+}
@@ -3,6 +3,7 @@
 package preveltekit
 
 import (
+	"strings"
 	"syscall/js"
 )
 
@@ -25,10 +26,12 @@ func ok(el js.Value) bool {
 	return !el.IsNull() && !el.IsUndefined()
 }
 
-// cleanupBag holds js.Func references and destroy callbacks for batch release.
+// cleanupBag holds js.Func references, store subscriptions and destroy
+// callbacks for batch release.
 // Use this to prevent memory leaks when components unmount or re-render.
 type cleanupBag struct {
 	funcs     []js.Func
+	unsubs    []func()
 	onDestroy []func()
 }
 
@@ -41,28 +44,61 @@ func (c *cleanupBag) Add(fn js.Func) {
 	c.funcs = append(c.funcs, fn)
 }
 
+// AddUnsub registers an unsubscribe func (from OnChange/OnChangeAny, or a
+// delegated event handler) to run on Release, so a released subtree stops
+// reacting to store changes and events.
+func (c *cleanupBag) AddUnsub(unsub func()) {
+	c.unsubs = append(c.unsubs, unsub)
+}
+
 // AddDestroy registers a destroy callback to run on Release.
 func (c *cleanupBag) AddDestroy(fn func()) {
 	c.onDestroy = append(c.onDestroy, fn)
 }
 
-// Release runs all destroy callbacks and frees all registered js.Func references.
-// Safe to call multiple times.
+// Release runs all destroy callbacks, removes all store subscriptions and
+// frees all registered js.Func references. Safe to call multiple times.
 func (c *cleanupBag) Release() {
 	for _, fn := range c.onDestroy {
 		fn()
 	}
 	c.onDestroy = nil
+	for _, unsub := range c.unsubs {
+		unsub()
+	}
+	c.unsubs = nil
 	for _, fn := range c.funcs {
 		fn.Release()
 	}
 	c.funcs = nil
 }
 
+// flushFn is the js.Func handed to queueMicrotask/requestAnimationFrame.
+// Created once and never released.
+var flushFn js.Func
+
+// requestFlush schedules flushPending for the given scheduler.
+func requestFlush(s Scheduler) {
+	if flushFn.Value.IsUndefined() {
+		flushFn = js.FuncOf(func(this js.Value, args []js.Value) any {
+			flushPending()
+			return nil
+		})
+	}
+	switch s {
+	case ScheduleMicrotask:
+		js.Global().Call("queueMicrotask", flushFn)
+	case ScheduleFrame:
+		js.Global().Call("requestAnimationFrame", flushFn)
+	default:
+		flushPending()
+	}
+}
+
 // bindable is implemented by types that can be bound to DOM elements.
 type bindable[T any] interface {
 	Get() T
-	OnChange(func(T))
+	OnChange(func(T)) func()
 }
 
 // findComment finds a comment node with the given marker text using TreeWalker
@@ -83,59 +119,331 @@ func findComment(marker string) js.Value {
 	}
 }
 
+// htmlToNodes parses an HTML string and returns its top-level nodes,
+// detached from any document position and ready to be inserted.
+func htmlToNodes(html string) []js.Value {
+	tmpl := document.Call("createElement", "template")
+	tmpl.Set("innerHTML", html)
+	childNodes := tmpl.Get("content").Get("childNodes")
+	nodes := make([]js.Value, childNodes.Length())
+	for i := range nodes {
+		nodes[i] = childNodes.Index(i)
+	}
+	return nodes
+}
+
 // settable extends bindable with Set capability for two-way binding
 type settable[T any] interface {
 	bindable[T]
 	Set(T)
 }
 
-// bindInput binds a text input to a string store (two-way).
-// Returns the js.Func for cleanup.
-func bindInput(id string, store settable[string]) js.Func {
+// bindInput binds a text input, textarea or select to a string store (two-way).
+// event is "input", or "change" for selects and lazy bindings.
+// The listener and store subscription are registered with c.
+func bindInput(c *cleanupBag, id, event string, store settable[string]) {
 	el := getEl(id)
 	if !ok(el) {
-		return js.Func{}
+		return
 	}
 	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
 		store.Set(this.Get("value").String())
 		return nil
 	})
-	el.Call("addEventListener", "input", fn)
-	store.OnChange(func(v string) { el.Set("value", v) })
-	return fn
+	el.Call("addEventListener", event, fn)
+	c.Add(fn)
+	c.AddUnsub(store.OnChange(func(v string) { el.Set("value", v) }))
 }
 
-// bindInputInt binds a text input to an int store (two-way).
-// Returns the js.Func for cleanup.
-func bindInputInt(id string, store settable[int]) js.Func {
+// bindInputInt binds a text input or select to an int store (two-way).
+// The listener and store subscription are registered with c.
+func bindInputInt(c *cleanupBag, id, event string, store settable[int]) {
 	el := getEl(id)
 	if !ok(el) {
-		return js.Func{}
+		return
 	}
 	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
 		store.Set(atoiSafe(this.Get("value").String()))
 		return nil
 	})
-	el.Call("addEventListener", "input", fn)
-	store.OnChange(func(v int) { el.Set("value", itoa(v)) })
-	return fn
+	el.Call("addEventListener", event, fn)
+	c.Add(fn)
+	c.AddUnsub(store.OnChange(func(v int) { el.Set("value", itoa(v)) }))
+}
+
+// bindInputFloat binds a text input or select to a float64 store (two-way).
+// Input that does not parse as a number (e.g. "" or "-" while typing) is
+// ignored instead of resetting the field to 0.
+// The listener and store subscription are registered with c.
+func bindInputFloat(c *cleanupBag, id, event string, store settable[float64]) {
+	el := getEl(id)
+	if !ok(el) {
+		return
+	}
+	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		f := js.Global().Call("parseFloat", this.Get("value")).Float()
+		if f == f { // not NaN
+			store.Set(f)
+		}
+		return nil
+	})
+	el.Call("addEventListener", event, fn)
+	c.Add(fn)
+	c.AddUnsub(store.OnChange(func(v float64) { el.Set("value", ftoa(v)) }))
+}
+
+// bindRadio binds one radio button of a group to a string store (two-way).
+// The store holds the value of the checked radio; every radio of the group
+// is bound to the same store.
+// The listener and store subscription are registered with c.
+func bindRadio(c *cleanupBag, id string, store settable[string]) {
+	el := getEl(id)
+	if !ok(el) {
+		return
+	}
+	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		if this.Get("checked").Bool() {
+			store.Set(this.Get("value").String())
+		}
+		return nil
+	})
+	el.Call("addEventListener", "change", fn)
+	c.Add(fn)
+	c.AddUnsub(store.OnChange(func(v string) { el.Set("checked", el.Get("value").String() == v) }))
+}
+
+// bindSelectMultiple binds a <select multiple> to a []string store (two-way).
+// The listener and store subscription are registered with c.
+func bindSelectMultiple(c *cleanupBag, id string, store settable[[]string]) {
+	el := getEl(id)
+	if !ok(el) {
+		return
+	}
+	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		selected := this.Get("selectedOptions")
+		values := make([]string, selected.Length())
+		for i := range values {
+			values[i] = selected.Index(i).Get("value").String()
+		}
+		store.Set(values)
+		return nil
+	})
+	el.Call("addEventListener", "change", fn)
+	c.Add(fn)
+	c.AddUnsub(store.OnChange(func(v []string) {
+		sel := make(map[string]bool, len(v))
+		for _, s := range v {
+			sel[s] = true
+		}
+		options := el.Get("options")
+		for i := 0; i < options.Length(); i++ {
+			opt := options.Index(i)
+			opt.Set("selected", sel[opt.Get("value").String()])
+		}
+	}))
 }
 
 // bindCheckbox binds a checkbox to a bool store (two-way).
-// Returns the js.Func for cleanup.
-func bindCheckbox(id string, store settable[bool]) js.Func {
+// The listener and store subscription are registered with c.
+func bindCheckbox(c *cleanupBag, id string, store settable[bool]) {
 	el := getEl(id)
 	if !ok(el) {
-		return js.Func{}
+		return
 	}
 	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
 		store.Set(this.Get("checked").Bool())
 		return nil
 	})
 	el.Call("addEventListener", "change", fn)
-	store.OnChange(func(v bool) { el.Set("checked", v) })
+	c.AddUnsub(store.OnChange(func(v bool) { el.Set("checked", v) }))
 	el.Set("checked", store.Get())
-	return fn
+	c.Add(fn)
+}
+
+// eventRef holds the native DOM event behind an Event.
+type eventRef struct {
+	v js.Value
+}
+
+// newEvent copies the commonly used fields of a DOM event into an Event.
+func newEvent(v js.Value) Event {
+	e := Event{ref: eventRef{v}}
+	if !ok(v) {
+		return e
+	}
+	e.Type = jsString(v.Get("type"))
+	e.Key = jsString(v.Get("key"))
+	e.Code = jsString(v.Get("code"))
+	e.CtrlKey = v.Get("ctrlKey").Truthy()
+	e.ShiftKey = v.Get("shiftKey").Truthy()
+	e.AltKey = v.Get("altKey").Truthy()
+	e.MetaKey = v.Get("metaKey").Truthy()
+	if b := v.Get("button"); b.Type() == js.TypeNumber {
+		e.Button = b.Int()
+	}
+	if x := v.Get("clientX"); x.Type() == js.TypeNumber {
+		e.ClientX = x.Float()
+		e.ClientY = v.Get("clientY").Float()
+	}
+	if t := v.Get("target"); ok(t) {
+		e.TargetID = jsString(t.Get("id"))
+		e.Value = jsString(t.Get("value"))
+		e.Checked = t.Get("checked").Truthy()
+	}
+	return e
+}
+
+// jsString returns v as a string, or "" if v is not a JS string.
+func jsString(v js.Value) string {
+	if v.Type() != js.TypeString {
+		return ""
+	}
+	return v.String()
+}
+
+// PreventDefault calls event.preventDefault().
+func (e Event) PreventDefault() {
+	if ok(e.ref.v) {
+		e.ref.v.Call("preventDefault")
+	}
+}
+
+// StopPropagation calls event.stopPropagation().
+func (e Event) StopPropagation() {
+	if ok(e.ref.v) {
+		e.ref.v.Call("stopPropagation")
+	}
+}
+
+// Data returns drag-and-drop data of the given format (dataTransfer.getData).
+// Returns "" for events without a dataTransfer.
+func (e Event) Data(format string) string {
+	if !ok(e.ref.v) {
+		return ""
+	}
+	dt := e.ref.v.Get("dataTransfer")
+	if !ok(dt) {
+		return ""
+	}
+	return jsString(dt.Call("getData", format))
+}
+
+// SetData stores drag-and-drop data of the given format (dataTransfer.setData).
+// Call it from a "dragstart" handler.
+func (e Event) SetData(format, data string) {
+	if !ok(e.ref.v) {
+		return
+	}
+	if dt := e.ref.v.Get("dataTransfer"); ok(dt) {
+		dt.Call("setData", format, data)
+	}
+}
+
+// elementRef holds the native DOM element behind an Element.
+type elementRef struct {
+	v js.Value
+}
+
+// newElement looks up the element with the given id.
+func newElement(id string) Element {
+	return Element{ID: id, ref: elementRef{getEl(id)}}
+}
+
+// Document returns a handle to the document, for listeners outside the
+// component (e.g. click-outside actions).
+func Document() Element {
+	return Element{ref: elementRef{document}}
+}
+
+// Target returns the element the event was dispatched to.
+func (e Event) Target() Element {
+	if !ok(e.ref.v) {
+		return Element{}
+	}
+	t := e.ref.v.Get("target")
+	if !ok(t) {
+		return Element{}
+	}
+	return Element{ID: jsString(t.Get("id")), ref: elementRef{t}}
+}
+
+// JS returns the underlying DOM element as an escape hatch for APIs not
+// covered by Element (canvas contexts, media playback, ...). It is undefined
+// for a zero Element.
+func (e Element) JS() js.Value {
+	return e.ref.v
+}
+
+// Focus calls element.focus().
+func (e Element) Focus() {
+	if ok(e.ref.v) {
+		e.ref.v.Call("focus")
+	}
+}
+
+// Blur calls element.blur().
+func (e Element) Blur() {
+	if ok(e.ref.v) {
+		e.ref.v.Call("blur")
+	}
+}
+
+// ScrollIntoView scrolls the element into the visible area, smoothly if
+// smooth is set.
+func (e Element) ScrollIntoView(smooth bool) {
+	if !ok(e.ref.v) {
+		return
+	}
+	opts := js.Global().Get("Object").New()
+	if smooth {
+		opts.Set("behavior", "smooth")
+	}
+	e.ref.v.Call("scrollIntoView", opts)
+}
+
+// On adds a native event listener directly on the element and returns a
+// func removing it. Unlike HtmlNode.On it is not delegated, so it also works
+// on the document and for events the tree does not declare. Store updates
+// inside fn are batched.
+func (e Element) On(event string, fn func(Event)) (remove func()) {
+	if !ok(e.ref.v) {
+		return func() {}
+	}
+	cb := js.FuncOf(func(this js.Value, args []js.Value) any {
+		Batch(func() { fn(newEvent(args[0])) })
+		return nil
+	})
+	e.ref.v.Call("addEventListener", event, cb)
+	return func() {
+		e.ref.v.Call("removeEventListener", event, cb)
+		cb.Release()
+	}
+}
+
+// Contains reports whether other is the element or one of its descendants.
+func (e Element) Contains(other Element) bool {
+	if !ok(e.ref.v) || !ok(other.ref.v) {
+		return false
+	}
+	return e.ref.v.Call("contains", other.ref.v).Bool()
+}
+
+// GetBoundingRect returns the element's getBoundingClientRect().
+func (e Element) GetBoundingRect() Rect {
+	if !ok(e.ref.v) {
+		return Rect{}
+	}
+	r := e.ref.v.Call("getBoundingClientRect")
+	return Rect{
+		X:      r.Get("x").Float(),
+		Y:      r.Get("y").Float(),
+		Width:  r.Get("width").Float(),
+		Height: r.Get("height").Float(),
+		Top:    r.Get("top").Float(),
+		Right:  r.Get("right").Float(),
+		Bottom: r.Get("bottom").Float(),
+		Left:   r.Get("left").Float(),
+	}
 }
 
 // === Batch Binding Types (for smaller WASM) ===
@@ -144,42 +452,224 @@ func bindCheckbox(id string, store settable[bool]) js.Func {
 type evt struct {
 	ID    string
 	Event string
-	Fn    func()
+	Fn    func(Event)
 }
 
-// bindEvents binds multiple events in a loop (smaller WASM than separate calls).
-// Pass a cleanup to collect js.Func references for later release.
-func bindEvents(c *cleanupBag, events []evt) {
+// bindEvents registers the handlers of one element with the delegated
+// event system. No listener is attached to the element itself: one listener
+// per event type on document dispatches by element id, so binding and
+// releasing handlers (e.g. in large each-blocks) costs no js.Func.
+func bindEvents(c *cleanupBag, elementID string, events []evt) {
 	for _, e := range events {
-		e := e // Capture loop variable for closure
-		el := getEl(e.ID)
-		if !ok(el) {
-			continue
-		}
-		mods := GetHandlerModifiers(e.ID)
-		fn := js.FuncOf(func(this js.Value, args []js.Value) any {
-			if len(args) > 0 {
-				ev := args[0]
-				for _, mod := range mods {
-					switch mod {
-					case "preventDefault":
-						ev.Call("preventDefault")
-					case "stopPropagation":
-						ev.Call("stopPropagation")
-					}
-				}
+		h := newDelegatedHandler(e.Fn, GetHandlerModifiers(e.ID))
+		key := e.Event
+		if h.passive {
+			key += ":passive"
+		}
+		delegate(e.Event, key, h.passive)
+		byEl := delegatedHandlers[key]
+		if byEl == nil {
+			byEl = make(map[string][]*delegatedHandler)
+			delegatedHandlers[key] = byEl
+		}
+		byEl[elementID] = append(byEl[elementID], h)
+		h.release = func() {
+			h.fn = nil // skipped by a dispatch already in progress
+			byEl[elementID] = removeCallback(byEl[elementID], h)
+			if len(byEl[elementID]) == 0 {
+				delete(byEl, elementID)
 			}
-			e.Fn()
-			return nil
-		})
-		el.Call("addEventListener", e.Event, fn)
-		c.Add(fn)
+		}
+		c.AddUnsub(h.release)
+	}
+}
+
+// delegatedHandler is one handler bound to an element id, with its
+// modifiers decoded from handlerModifiers.
+type delegatedHandler struct {
+	fn      func(Event) // nil once released
+	release func()
+
+	preventDefault  bool
+	stopPropagation bool
+	once            bool
+	capture         bool
+	passive         bool
+	self            bool
+	keys            []string // key combos, any of which must match
+	buttons         []string // mouse buttons, any of which must match
+}
+
+// newDelegatedHandler decodes modifiers into a delegatedHandler.
+func newDelegatedHandler(fn func(Event), mods []string) *delegatedHandler {
+	h := &delegatedHandler{fn: fn}
+	for _, mod := range mods {
+		switch {
+		case mod == "preventDefault":
+			h.preventDefault = true
+		case mod == "stopPropagation":
+			h.stopPropagation = true
+		case mod == "once":
+			h.once = true
+		case mod == "capture":
+			h.capture = true
+		case mod == "passive":
+			h.passive = true
+		case mod == "self":
+			h.self = true
+		case strings.HasPrefix(mod, "key:"):
+			h.keys = append(h.keys, mod[len("key:"):])
+		case strings.HasPrefix(mod, "button:"):
+			h.buttons = append(h.buttons, mod[len("button:"):])
+		}
+	}
+	return h
+}
+
+// accepts reports whether the key and button filters let ev through.
+func (h *delegatedHandler) accepts(ev Event) bool {
+	if len(h.keys) > 0 {
+		matched := false
+		for _, combo := range h.keys {
+			if matchKeyCombo(combo, ev) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(h.buttons) > 0 {
+		for _, b := range h.buttons {
+			if matchButton(b, ev) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// delegatedHandlers maps listener key (event type, plus ":passive" for
+// passive handlers) → element id → handlers.
+var delegatedHandlers = make(map[string]map[string][]*delegatedHandler)
+
+// delegateFns holds the document listener for each listener key. Created on
+// first use and never released.
+var delegateFns = make(map[string]js.Func)
+
+// nonBubbling lists event types that do not bubble. They are caught in the
+// capture phase on document and dispatched to the target element only.
+var nonBubbling = map[string]bool{
+	"focus": true, "blur": true, "load": true, "error": true, "scroll": true,
+	"mouseenter": true, "mouseleave": true, "pointerenter": true, "pointerleave": true,
+	"toggle": true, "invalid": true, "play": true, "pause": true, "ended": true,
+}
+
+// delegate installs the document listener for a listener key if needed.
+// Passive handlers get their own passive listener so the browser does not
+// wait on non-passive ones.
+func delegate(eventType, key string, passive bool) {
+	if _, exists := delegateFns[key]; exists {
+		return
 	}
+	targetOnly := nonBubbling[eventType]
+	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) > 0 {
+			dispatchEvent(key, args[0], targetOnly)
+		}
+		return nil
+	})
+	delegateFns[key] = fn
+	opts := js.Global().Get("Object").New()
+	opts.Set("capture", targetOnly)
+	opts.Set("passive", passive)
+	document.Call("addEventListener", eventType, fn, opts)
+}
+
+// delegatedHop is an element on the event path that has handlers.
+type delegatedHop struct {
+	el       js.Value
+	handlers []*delegatedHandler
+}
+
+// dispatchEvent collects the elements with handlers on the path from the
+// event target to the root, then runs Capture handlers root → target and the
+// others target → root, the way native capture and bubbling would.
+// A stopPropagation modifier (or e.StopPropagation()) ends dispatch after the
+// current element and calls stopImmediatePropagation, so other listeners on
+// document (the router's link handling among them) do not see the event
+// either. All handlers of one event run inside a single Batch.
+func dispatchEvent(key string, native js.Value, targetOnly bool) {
+	byEl := delegatedHandlers[key]
+	if len(byEl) == 0 {
+		return
+	}
+	target := native.Get("target")
+	var path []delegatedHop
+	for el := target; ok(el); el = el.Get("parentElement") {
+		if handlers := byEl[jsString(el.Get("id"))]; len(handlers) > 0 {
+			path = append(path, delegatedHop{el, handlers})
+		}
+		if targetOnly {
+			break
+		}
+	}
+	if len(path) == 0 {
+		return
+	}
+
+	ev := newEvent(native)
+	run := func(hop delegatedHop, capture bool) (stop bool) {
+		for _, h := range hop.handlers {
+			if h.fn == nil || h.capture != capture {
+				continue
+			}
+			if h.self && !hop.el.Equal(target) {
+				continue
+			}
+			if !h.accepts(ev) {
+				continue
+			}
+			if h.preventDefault {
+				native.Call("preventDefault")
+			}
+			if h.stopPropagation {
+				native.Call("stopPropagation")
+				stop = true
+			}
+			fn := h.fn
+			if h.once {
+				h.release()
+			}
+			fn(ev)
+		}
+		return stop || native.Get("cancelBubble").Truthy()
+	}
+	stop := func() {
+		native.Call("stopImmediatePropagation")
+	}
+	Batch(func() {
+		for i := len(path) - 1; i >= 0; i-- {
+			if run(path[i], true) {
+				stop()
+				return
+			}
+		}
+		for _, hop := range path {
+			if run(hop, false) {
+				stop()
+				return
+			}
+		}
+	})
 }
 
 // inp represents an input binding for batch processing
 type inp struct {
 	ID    string
+	Event string
 	Store settable[string]
 }
 
@@ -187,7 +677,7 @@ type inp struct {
 // Pass a cleanup to collect js.Func references for later release.
 func bindInputs(c *cleanupBag, bindings []inp) {
 	for _, b := range bindings {
-		c.Add(bindInput(b.ID, b.Store))
+		bindInput(c, b.ID, b.Event, b.Store)
 	}
 }
 
@@ -201,6 +691,6 @@ type chk struct {
 // Pass a cleanup to collect js.Func references for later release.
 func bindCheckboxes(c *cleanupBag, bindings []chk) {
 	for _, b := range bindings {
-		c.Add(bindCheckbox(b.ID, b.Store))
+		bindCheckbox(c, b.ID, b.Store)
 	}
 }
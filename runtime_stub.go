@@ -20,19 +20,73 @@ type cleanupBag struct{}
 // Add is a no-op for SSR.
 func (c *cleanupBag) Add(fn jsFunc) {}
 
+// AddUnsub is a no-op for SSR.
+func (c *cleanupBag) AddUnsub(unsub func()) {}
+
 // AddDestroy is a no-op for SSR.
 func (c *cleanupBag) AddDestroy(fn func()) {}
 
 // Release is a no-op for SSR.
 func (c *cleanupBag) Release() {}
 
+// requestFlush flushes immediately: SSR renders synchronously, so queued
+// notifications must be delivered before the HTML is generated.
+func requestFlush(s Scheduler) { flushPending() }
+
+// eventRef is empty in SSR: handlers never run natively.
+type eventRef struct{}
+
+// PreventDefault is a no-op for SSR.
+func (e Event) PreventDefault() {}
+
+// StopPropagation is a no-op for SSR.
+func (e Event) StopPropagation() {}
+
+// Data returns "" for SSR.
+func (e Event) Data(format string) string { return "" }
+
+// SetData is a no-op for SSR.
+func (e Event) SetData(format, data string) {}
+
+// elementRef is empty in SSR: there is no DOM.
+type elementRef struct{}
+
+func newElement(id string) Element { return Element{ID: id} }
+
+// Document returns a zero Element for SSR.
+func Document() Element { return Element{} }
+
+// Target returns a zero Element for SSR.
+func (e Event) Target() Element { return Element{} }
+
+// JS returns a no-op placeholder for js.Value in SSR.
+func (e Element) JS() *jsValue { return &jsValue{} }
+
+// Focus is a no-op for SSR.
+func (e Element) Focus() {}
+
+// Blur is a no-op for SSR.
+func (e Element) Blur() {}
+
+// ScrollIntoView is a no-op for SSR.
+func (e Element) ScrollIntoView(smooth bool) {}
+
+// On is a no-op for SSR.
+func (e Element) On(event string, fn func(Event)) (remove func()) { return func() {} }
+
+// Contains returns false for SSR.
+func (e Element) Contains(other Element) bool { return false }
+
+// GetBoundingRect returns a zero Rect for SSR.
+func (e Element) GetBoundingRect() Rect { return Rect{} }
+
 // jsFunc is a stub for js.Func in non-WASM builds
 type jsFunc struct{}
 
 // bindable is implemented by types that can be bound to DOM elements.
 type bindable[T any] interface {
 	Get() T
-	OnChange(func(T))
+	OnChange(func(T)) func()
 }
 
 // settable extends bindable with Set capability for two-way binding
@@ -41,21 +95,25 @@ type settable[T any] interface {
 	Set(T)
 }
 
-func bindInput(id string, store settable[string]) jsFunc  { return jsFunc{} }
-func bindInputInt(id string, store settable[int]) jsFunc  { return jsFunc{} }
-func bindCheckbox(id string, store settable[bool]) jsFunc { return jsFunc{} }
+func bindInput(c *cleanupBag, id, event string, store settable[string])       {}
+func bindInputInt(c *cleanupBag, id, event string, store settable[int])       {}
+func bindInputFloat(c *cleanupBag, id, event string, store settable[float64]) {}
+func bindCheckbox(c *cleanupBag, id string, store settable[bool])             {}
+func bindRadio(c *cleanupBag, id string, store settable[string])              {}
+func bindSelectMultiple(c *cleanupBag, id string, store settable[[]string])   {}
 
 // Batch binding types and functions (stubs for SSR)
 type evt struct {
 	ID    string
 	Event string
-	Fn    func()
+	Fn    func(Event)
 }
 
-func bindEvents(c *cleanupBag, events []evt) {}
+func bindEvents(c *cleanupBag, elementID string, events []evt) {}
 
 type inp struct {
 	ID    string
+	Event string
 	Store settable[string]
 }
 
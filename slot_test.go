@@ -0,0 +1,55 @@
+//go:build !wasm
+
+package preveltekit
+
+import "testing"
+
+type slotCard struct{ open *Store[bool] }
+
+func (c *slotCard) Render() Node {
+	return Div(
+		Header(SlotNamed("header", H2("Untitled"))),
+		If(Cond(c.open.Get, c.open), Slot(P("empty"))),
+		Footer(SlotNamed("footer")),
+	)
+}
+
+type slotList struct{ items *List[string] }
+
+func (l *slotList) Render() Node {
+	return Ul(Each(l.items, func(item string, i int) Node {
+		return Li(SlotNamed("item", Span(item)).With(item))
+	}))
+}
+
+func TestSlots(t *testing.T) {
+	open := New(true)
+
+	// Fallbacks when nothing is passed
+	html := nodeToHTML(Comp(&slotCard{open: open}), NewBuildContext())
+	want := `<div><header><h2>Untitled</h2></header><!--c0_i0s--><p>empty</p><!--c0_i0--><footer></footer></div>`
+	if html != want {
+		t.Errorf("fallback:\n got %s\nwant %s", html, want)
+	}
+
+	// Named fills and default content (also inside an if-block)
+	html = nodeToHTML(Comp(&slotCard{open: open},
+		Fill("header", H1("Welcome")),
+		P("body"),
+		Fill("footer", Small("fine print")),
+	), NewBuildContext())
+	want = `<div><header><h1>Welcome</h1></header><!--c0_i0s--><p>body</p><!--c0_i0--><footer><small>fine print</small></footer></div>`
+	if html != want {
+		t.Errorf("named:\n got %s\nwant %s", html, want)
+	}
+
+	// Scoped fill receives the child's data
+	items := NewList("a", "b")
+	html = nodeToHTML(Comp(&slotList{items: items},
+		FillWith("item", func(item string) Node { return Strong(item + "!") }),
+	), NewBuildContext())
+	want = `<ul><!--c0_e0s--><li><strong>a!</strong></li><li><strong>b!</strong></li><!--c0_e0--></ul>`
+	if html != want {
+		t.Errorf("scoped:\n got %s\nwant %s", html, want)
+	}
+}
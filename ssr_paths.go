@@ -0,0 +1,55 @@
+package preveltekit
+
+import "strings"
+
+// ssrPage is one page to pre-render. Pages of Redirect routes get a
+// meta-refresh document pointing at redirect instead of the rendered app.
+type ssrPage struct {
+	StaticPath
+	redirect string
+}
+
+// collectSSRPaths lists every page to pre-render: the SSRPath of plain routes
+// plus each concrete path returned by a route's StaticPaths hook, including
+// nested child routes. In WASM, hash mode uses the same list to find the page
+// the document was rendered for (prerenderedPath).
+func collectSSRPaths(routes []Route) []ssrPage {
+	var pages []ssrPage
+	for _, entry := range flattenRoutes(routes, "", nil) {
+		route := entry.route()
+		var paths []StaticPath
+		if route.SSRPath != "" {
+			paths = append(paths, StaticPath{Path: route.SSRPath, HTMLFile: route.HTMLFile})
+		}
+		if route.StaticPaths != nil {
+			for _, sp := range route.StaticPaths() {
+				if sp.Path == "" {
+					continue
+				}
+				if sp.HTMLFile == "" {
+					sp.HTMLFile = htmlFileForPath(sp.Path)
+				}
+				paths = append(paths, sp)
+			}
+		}
+		for _, sp := range paths {
+			page := ssrPage{StaticPath: sp}
+			if route.Redirect != "" {
+				params, _, _ := matchRoute(resolveRoute("/", entry.pattern), sp.Path)
+				page.redirect = resolveRoute("/", expandRedirect(route.Redirect, params))
+			}
+			pages = append(pages, page)
+		}
+	}
+	return pages
+}
+
+// htmlFileForPath derives an output filename from a URL path.
+// "/" → "index.html", "/blog/hello" → "blog/hello.html".
+func htmlFileForPath(path string) string {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return "index.html"
+	}
+	return trimmed + ".html"
+}
@@ -1,6 +1,8 @@
 // Package reactive provides generic reactive stores for Go WebAssembly applications.
 package preveltekit
 
+import "reflect"
+
 // Component is the interface that all declarative components must implement.
 type Component interface {
 	Render() Node
@@ -8,10 +10,26 @@ type Component interface {
 
 // Route defines a single route for both build-time pre-rendering and runtime routing.
 type Route struct {
-	Path      string    // URL path pattern (e.g., "/user/:id")
-	HTMLFile  string    // Output filename for pre-rendering (e.g., "user.html")
-	SSRPath   string    // URL to pre-render (empty = skip SSR)
-	Component Component // Component to render for this route
+	Path        string              // URL path pattern (e.g., "/user/:id")
+	HTMLFile    string              // Output filename for pre-rendering (e.g., "user.html")
+	SSRPath     string              // URL to pre-render (empty = skip SSR)
+	StaticPaths func() []StaticPath // Concrete paths to pre-render for a parameterised Path (build time only)
+	Component   Component           // Component to render for this route
+	Children    []Route             // Nested routes, shown in the Component's Outlet(); Paths are relative to this one
+	Redirect    string              // Path to redirect to instead of showing a component; ":name" segments take the matched params
+
+	Guard   func(rc RouteContext) (redirect string) // Runs before the route (and its children) is shown; return a path to redirect, "" to allow
+	Load    func(rc RouteContext) (any, error)      // Loads the route's data before it is shown (see Router.Data)
+	Pending Component                               // Shown while Guard and Load run (WASM); empty = keep the current page
+	Error   Component                               // Shown instead of Component when Load fails (see Router.LoadError)
+}
+
+// StaticPath is one concrete page of a parameterised route.
+// Returned by Route.StaticPaths so a pattern like "/blog/:slug" can be
+// pre-rendered once per post.
+type StaticPath struct {
+	Path     string // URL to pre-render (e.g., "/blog/hello-world")
+	HTMLFile string // Output filename (empty = derived from Path, e.g., "blog/hello-world.html")
 }
 
 // ComponentRoot is the root app component passed to Hydrate().
@@ -60,15 +78,33 @@ type AnyGetter interface {
 
 // AnySubscriber is implemented by stores to subscribe without knowing the value type.
 type AnySubscriber interface {
-	OnChangeAny(func())
+	OnChangeAny(func()) (unsubscribe func())
+}
+
+// AnyList is implemented by lists to iterate and subscribe without knowing the item type.
+// Each-blocks use it to render a List[T] of any comparable T.
+type AnyList interface {
+	AnySubscriber
+	ItemsAny() []any
 }
 
 // Store is a generic reactive container that calls callbacks on mutation
 type Store[T any] struct {
-	id        string
-	value     T
-	callbacks []func(T)
-	options   []any // possible values for pre-baked rendering (used by Store[Component])
+	id         string
+	value      T
+	callbacks  []*func(T)
+	options    []any             // possible values for pre-baked rendering (used by Store[Component])
+	transition *Transition       // animates component swaps (set by WithTransition)
+	equal      func(a, b T) bool // Set skips notification when it reports true; nil = always notify
+	remounts   int               // bumped by remount to re-render an unchanged component (used by Store[Component])
+
+	// Computed stores only
+	compute func() T // derives the value; nil for plain stores
+	fresh   bool     // value is up to date and deps are subscribed
+	deps    []func() // unsubscribe funcs for the tracked dependencies
+
+	queued    bool      // a notification is pending in a Batch or scheduler flush
+	observers []*func() // Computed stores depending on this one, invalidated synchronously
 }
 
 // WithOptions registers alternative values this store may hold.
@@ -86,6 +122,13 @@ func (s *Store[T]) Options() []any {
 	return s.options
 }
 
+// remount notifies the subscribers of a Store[Component] so its block
+// renders the current component again, although the value did not change.
+func (s *Store[T]) remount() {
+	s.remounts++
+	s.changed()
+}
+
 // storeRegistry holds all registered stores by ID for hydration lookup
 var storeRegistry = make(map[string]any)
 
@@ -107,15 +150,28 @@ func resetRegistries() {
 	scopeCounter = 0
 	storeRegistry = make(map[string]any)
 	handlerRegistry = make(map[string]func())
+	eventHandlerRegistry = make(map[string]func(Event))
+	componentHandlerRegistry = make(map[string]any)
 	handlerModifiers = make(map[string][]string)
 	scopeRegistry = make(map[string]string)
+	rootScope = &componentScope{}
+	activeScope = nil
 }
 
 // handlerRegistry holds all registered event handlers by ID for hydration lookup
 var handlerRegistry = make(map[string]func())
 
-// handlerModifiers holds event modifiers (preventDefault, stopPropagation) by handler ID.
-// Set by PreventDefault()/StopPropagation() after On() registers the handler.
+// eventHandlerRegistry holds handlers registered with OnEvent, which receive the DOM event.
+// Shares the h<N> ID space with handlerRegistry.
+var eventHandlerRegistry = make(map[string]func(Event))
+
+// componentHandlerRegistry holds handlers subscribed to component events with
+// ComponentNode.OnEvent (func(T) or func()). Shares the h<N> ID space with handlerRegistry.
+var componentHandlerRegistry = make(map[string]any)
+
+// handlerModifiers holds event modifiers by handler ID: preventDefault,
+// stopPropagation, once, capture, passive, self, and filters "key:<combo>"
+// and "button:<name>". Set by the modifier methods after On() registers the handler.
 var handlerModifiers = make(map[string][]string)
 
 // handlerCounter generates unique auto-IDs for event handlers
@@ -133,6 +189,11 @@ func GetHandler(id string) func() {
 	return handlerRegistry[id]
 }
 
+// GetEventHandler looks up an OnEvent handler by ID from the global registry
+func GetEventHandler(id string) func(Event) {
+	return eventHandlerRegistry[id]
+}
+
 // GetHandlerModifiers returns the modifiers for a handler ID (e.g., ["preventDefault"])
 func GetHandlerModifiers(id string) []string {
 	return handlerModifiers[id]
@@ -166,6 +227,14 @@ func RegisterHandler(handler func()) string {
 	return id
 }
 
+// RegisterEventHandler registers an event handler that receives the DOM event,
+// auto-generating a unique ID. Returns the generated ID.
+func RegisterEventHandler(handler func(Event)) string {
+	id := nextHandlerID()
+	eventHandlerRegistry[id] = handler
+	return id
+}
+
 // LocalStore is a Store[string] that automatically syncs with localStorage.
 // Use it for persisting string values across page reloads.
 //
@@ -179,65 +248,321 @@ type LocalStore struct {
 // New creates a reactive store with an auto-generated ID and initial value.
 // The ID is deterministic (counter-based) so SSR and WASM produce matching IDs
 // when stores are created in the same order.
+// Stores of basic types (string, bool, numbers, and named types based on
+// them) skip Set calls that do not change the value. Use NewWithEqual for other types.
 func New[T any](initial T) *Store[T] {
-	id := nextStoreID()
-	s := &Store[T]{id: id, value: initial}
-	storeRegistry[id] = s
+	return newWithID(nextStoreID(), initial)
+}
+
+// NewWithEqual creates a reactive store that uses equal to decide whether
+// Set changed the value. Pass nil to notify on every Set.
+//
+// Example:
+//
+//	user := p.NewWithEqual(User{}, func(a, b User) bool { return a.ID == b.ID })
+func NewWithEqual[T any](initial T, equal func(a, b T) bool) *Store[T] {
+	s := New(initial)
+	s.equal = equal
 	return s
 }
 
 // newWithID creates a reactive store with an explicit ID and initial value.
 // Internal only — used by router, localStorage, and List.Len() where a predictable ID is needed.
 func newWithID[T any](id string, initial T) *Store[T] {
-	s := &Store[T]{id: id, value: initial}
+	s := &Store[T]{id: id, value: initial, equal: defaultEqual[T]()}
 	storeRegistry[id] = s
 	return s
 }
 
+// defaultEqual returns an equality func for basic types, including named
+// ones (type Status string), nil otherwise. Other types may not be
+// comparable (slices, maps), so they always notify.
+func defaultEqual[T any]() func(a, b T) bool {
+	switch reflect.TypeOf((*T)(nil)).Elem().Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return basicEqual[T]
+	}
+	return nil
+}
+
+// basicEqual compares two values of a basic type.
+func basicEqual[T any](a, b T) bool {
+	return any(a) == any(b)
+}
+
 // ID returns the store's unique identifier
 func (s *Store[T]) ID() string {
 	return s.id
 }
 
-// Get returns the current value
+// Get returns the current value.
+// Inside a Computed function, the store is recorded as a dependency.
 func (s *Store[T]) Get() T {
+	track(s)
+	if s.compute != nil && !s.fresh {
+		s.evaluate()
+	}
 	return s.value
 }
 
-// Set updates the value and calls all callbacks
+// Set updates the value and calls all callbacks.
+// Setting a value equal to the current one is a no-op (see New, NewWithEqual).
+// Inside Batch, or with an async scheduler, the callbacks run at the next flush.
 func (s *Store[T]) Set(v T) {
+	if s.equal != nil && s.compute == nil && s.equal(s.value, v) {
+		return
+	}
 	s.value = v
-	s.notify()
+	invalidateAll(s.observers)
+	s.changed()
 }
 
 // Update applies a function to transform the current value
 func (s *Store[T]) Update(fn func(T) T) {
-	s.Set(fn(s.value))
+	s.Set(fn(s.Get()))
 }
 
-// OnChange adds a callback that runs whenever the value changes
-func (s *Store[T]) OnChange(cb func(T)) {
-	s.callbacks = append(s.callbacks, cb)
+// OnChange adds a callback that runs whenever the value changes.
+// Call the returned func to unsubscribe; it is safe to call more than once.
+func (s *Store[T]) OnChange(cb func(T)) (unsubscribe func()) {
+	ref := &cb
+	s.callbacks = append(s.callbacks, ref)
+	// A computed store needs its dependencies subscribed once someone listens
+	if s.compute != nil && !s.fresh {
+		s.evaluate()
+	}
+	return func() {
+		*ref = nil // skipped by a notify already in progress
+		s.callbacks = removeCallback(s.callbacks, ref)
+		if s.compute != nil && !s.hasListeners() {
+			s.releaseDeps() // last subscriber gone — stop tracking
+		}
+	}
 }
 
 // GetAny returns the current value as any.
-func (s *Store[T]) GetAny() any { return s.value }
+func (s *Store[T]) GetAny() any { return s.Get() }
 
 // OnChangeAny adds a callback that runs on change without receiving the value.
-func (s *Store[T]) OnChangeAny(fn func()) { s.OnChange(func(_ T) { fn() }) }
+func (s *Store[T]) OnChangeAny(fn func()) func() { return s.OnChange(func(_ T) { fn() }) }
+
+// changed notifies subscribers now, or queues one notification if
+// notifications are deferred.
+func (s *Store[T]) changed() {
+	if !deferNotify() {
+		s.flush()
+		return
+	}
+	if s.queued {
+		return
+	}
+	s.queued = true
+	enqueueNotify(s.flush)
+}
+
+// flush delivers a (possibly queued) notification with the current value.
+func (s *Store[T]) flush() {
+	s.queued = false
+	if s.compute != nil && !s.fresh {
+		if len(s.callbacks) == 0 {
+			return
+		}
+		s.evaluate()
+	}
+	s.notify()
+}
 
 func (s *Store[T]) notify() {
 	for _, cb := range s.callbacks {
-		cb(s.value)
+		if *cb != nil {
+			(*cb)(s.value)
+		}
+	}
+}
+
+// Computed creates a store whose value is derived from other stores.
+// Every Store.Get and List.Get call made while fn runs is recorded as a
+// dependency. The value is recomputed lazily: while nobody subscribes, Get
+// re-runs fn on every call and no subscriptions are held, so an unused
+// Computed never leaks. Once subscribed (Bind, Cond, Attr, OnChange), it
+// caches the value, recomputes when a dependency changes, and re-subscribes
+// if fn read a different set of stores.
+//
+// The result is a regular *Store[T] and works anywhere a store is accepted.
+//
+// Dependencies are tracked through package state, so create, read and
+// subscribe Computed stores on the render goroutine only, and do not block
+// in fn. Code running in a goroutine (a FormState submit func, a route
+// Load in WASM) may read plain stores, but not Computed ones. In WASM all
+// goroutines share one thread and only switch when blocked, so a
+// non-blocking fn cannot interleave with another goroutine's evaluation.
+//
+// Example:
+//
+//	fullName := p.Computed(func() string {
+//	    return first.Get() + " " + last.Get()
+//	})
+func Computed[T any](fn func() T) *Store[T] {
+	s := New(*new(T))
+	s.compute = fn
+	return s
+}
+
+// evaluate runs the compute function, tracking the stores it reads.
+// Dependencies are only subscribed while the computed store has subscribers
+// (callbacks or other Computed stores). New dependencies are subscribed before
+// the old ones are dropped, so a chained Computed keeps its own deps alive.
+func (s *Store[T]) evaluate() {
+	var deps []dependency
+	trackStack = append(trackStack, &deps)
+	s.value = s.compute()
+	trackStack = trackStack[:len(trackStack)-1]
+
+	old := s.deps
+	s.deps = nil
+	s.fresh = false
+	if s.hasListeners() {
+		for _, d := range deps {
+			s.deps = append(s.deps, d.observe(s.invalidate))
+		}
+		s.fresh = true
+	}
+	for _, unobserve := range old {
+		unobserve()
+	}
+}
+
+// hasListeners reports whether anything is subscribed to the store.
+func (s *Store[T]) hasListeners() bool {
+	return len(s.callbacks) > 0 || len(s.observers) > 0
+}
+
+// invalidate marks the cached value stale after a dependency changed.
+// Subscribed computed stores recompute and notify (once per flush when deferred).
+func (s *Store[T]) invalidate() {
+	s.fresh = false
+	invalidateAll(s.observers)
+	if len(s.callbacks) > 0 {
+		s.changed()
+	}
+}
+
+// releaseDeps unsubscribes from all tracked dependencies.
+func (s *Store[T]) releaseDeps() {
+	for _, unsub := range s.deps {
+		unsub()
+	}
+	s.deps = nil
+	s.fresh = false
+}
+
+// dependency is implemented by stores and lists that Computed can track.
+// Observers are invalidated synchronously on every change, even inside Batch,
+// so a Computed read mid-batch never returns a stale value.
+type dependency interface {
+	observe(fn func()) (unobserve func())
+}
+
+// trackStack holds the dependency sets of the Computed functions currently
+// running (innermost last). Store.Get and List.Get record into the top set.
+// Get has no way to reach its caller's evaluation, so this is package state
+// and Computed is limited to the render goroutine (see Computed).
+var trackStack []*[]dependency
+
+// track records d as a dependency of the innermost running Computed function.
+func track(d dependency) {
+	if len(trackStack) == 0 {
+		return
+	}
+	deps := trackStack[len(trackStack)-1]
+	for _, existing := range *deps {
+		if existing == d {
+			return
+		}
+	}
+	*deps = append(*deps, d)
+}
+
+// observe registers a Computed invalidation callback.
+func (s *Store[T]) observe(fn func()) func() {
+	ref := &fn
+	s.observers = append(s.observers, ref)
+	if s.compute != nil && !s.fresh {
+		s.evaluate() // chained Computed: track our own deps too
+	}
+	return func() {
+		*ref = nil
+		s.observers = removeCallback(s.observers, ref)
+		if s.compute != nil && !s.hasListeners() {
+			s.releaseDeps()
+		}
+	}
+}
+
+// observe registers a Computed invalidation callback.
+func (l *List[T]) observe(fn func()) func() {
+	ref := &fn
+	l.observers = append(l.observers, ref)
+	return func() {
+		*ref = nil
+		l.observers = removeCallback(l.observers, ref)
 	}
 }
 
+// invalidateAll calls the observer callbacks that are still registered.
+func invalidateAll(observers []*func()) {
+	for _, fn := range observers {
+		if *fn != nil {
+			(*fn)()
+		}
+	}
+}
+
+// removeCallback returns callbacks without cb. It always allocates a new
+// slice so a notify loop ranging over the old slice is not disturbed.
+func removeCallback[F any](callbacks []*F, cb *F) []*F {
+	out := make([]*F, 0, len(callbacks))
+	for _, c := range callbacks {
+		if c != cb {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ListOpKind identifies the kind of a list mutation.
+type ListOpKind int
+
+const (
+	ListInsert  ListOpKind = iota // Items inserted at Index
+	ListRemove                    // one item removed at Index
+	ListMove                      // one item moved from Index to To
+	ListReplace                   // item at Index replaced by Items[0]
+	ListClear                     // all items removed
+	ListReset                     // whole list replaced by Items (Set)
+)
+
+// ListOp describes a single list mutation, delivered to OnOp subscribers.
+type ListOp[T any] struct {
+	Kind  ListOpKind
+	Index int // affected index (source index for ListMove)
+	To    int // destination index (ListMove only)
+	Items []T // inserted items (ListInsert), new value (ListReplace), new list (ListReset)
+}
+
 // List is a reactive slice with methods that trigger updates
 type List[T comparable] struct {
 	id       string
 	items    []T
 	lenStore *Store[int] // cached length store for reactive conditions
-	onChange []func([]T)
+	onChange []*func([]T)
+	onOp     []*func(ListOp[T])
+
+	pendingOps []ListOp[T] // ops recorded while notifications are deferred
+	queued     bool
+	observers  []*func() // Computed stores depending on this list
 }
 
 // NewList creates a reactive list with an auto-generated ID.
@@ -258,8 +583,10 @@ func (l *List[T]) ID() string {
 	return l.id
 }
 
-// Get returns a copy of the slice (safe, no mutation leaks)
+// Get returns a copy of the slice (safe, no mutation leaks).
+// Inside a Computed function, the list is recorded as a dependency.
 func (l *List[T]) Get() []T {
+	track(l)
 	cp := make([]T, len(l.items))
 	copy(cp, l.items)
 	return cp
@@ -279,42 +606,161 @@ func (l *List[T]) Len() *Store[int] {
 
 // At returns item at index
 func (l *List[T]) At(i int) T {
+	track(l)
 	return l.items[i]
 }
 
-// Set replaces the entire list
+// Set replaces the entire list. Setting identical contents is a no-op.
 func (l *List[T]) Set(items []T) {
+	if equalItems(l.items, items) {
+		return
+	}
 	l.items = items
-	for _, cb := range l.onChange {
-		cb(l.items)
+	l.notify(ListOp[T]{Kind: ListReset, Items: items})
+}
+
+// equalItems reports whether a and b hold the same items in the same order.
+func equalItems[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
 	}
+	return true
 }
 
 // Append adds items to the end
 func (l *List[T]) Append(items ...T) {
+	i := len(l.items)
 	l.items = append(l.items, items...)
-	for _, cb := range l.onChange {
-		cb(l.items)
-	}
+	l.notify(ListOp[T]{Kind: ListInsert, Index: i, Items: items})
+}
+
+// InsertAt inserts items before index i (i == Len appends)
+func (l *List[T]) InsertAt(i int, items ...T) {
+	l.items = append(l.items[:i], append(append([]T{}, items...), l.items[i:]...)...)
+	l.notify(ListOp[T]{Kind: ListInsert, Index: i, Items: items})
 }
 
 // RemoveAt removes item at index
 func (l *List[T]) RemoveAt(i int) {
 	l.items = append(l.items[:i], l.items[i+1:]...)
-	for _, cb := range l.onChange {
-		cb(l.items)
+	l.notify(ListOp[T]{Kind: ListRemove, Index: i})
+}
+
+// SetAt replaces the item at index. Setting an equal item is a no-op.
+func (l *List[T]) SetAt(i int, item T) {
+	if l.items[i] == item {
+		return
 	}
+	l.items[i] = item
+	l.notify(ListOp[T]{Kind: ListReplace, Index: i, Items: []T{item}})
+}
+
+// Move moves the item at index from to index to (an index in the resulting list)
+func (l *List[T]) Move(from, to int) {
+	item := l.items[from]
+	l.items = append(l.items[:from], l.items[from+1:]...)
+	l.items = append(l.items[:to], append([]T{item}, l.items[to:]...)...)
+	l.notify(ListOp[T]{Kind: ListMove, Index: from, To: to})
+}
+
+// Swap exchanges the items at indices i and j (reported as two replacements)
+func (l *List[T]) Swap(i, j int) {
+	l.items[i], l.items[j] = l.items[j], l.items[i]
+	l.notify(
+		ListOp[T]{Kind: ListReplace, Index: i, Items: []T{l.items[i]}},
+		ListOp[T]{Kind: ListReplace, Index: j, Items: []T{l.items[j]}},
+	)
 }
 
 // Clear removes all items
 func (l *List[T]) Clear() {
 	l.items = l.items[:0]
+	l.notify(ListOp[T]{Kind: ListClear})
+}
+
+// OnChange adds a callback for any change to the list.
+// Call the returned func to unsubscribe.
+func (l *List[T]) OnChange(cb func([]T)) (unsubscribe func()) {
+	ref := &cb
+	l.onChange = append(l.onChange, ref)
+	return func() {
+		*ref = nil
+		l.onChange = removeCallback(l.onChange, ref)
+	}
+}
+
+// OnOp adds a callback that receives each mutation as a ListOp.
+// Op callbacks run before OnChange callbacks, in mutation order.
+//
+// Example:
+//
+//	items.OnOp(func(op p.ListOp[string]) {
+//	    if op.Kind == p.ListInsert {
+//	        log("inserted", len(op.Items), "at", op.Index)
+//	    }
+//	})
+func (l *List[T]) OnOp(cb func(ListOp[T])) (unsubscribe func()) {
+	ref := &cb
+	l.onOp = append(l.onOp, ref)
+	return func() {
+		*ref = nil
+		l.onOp = removeCallback(l.onOp, ref)
+	}
+}
+
+// notify delivers ops now, or records them for the next flush if
+// notifications are deferred. OnChange subscribers then run once per flush.
+func (l *List[T]) notify(ops ...ListOp[T]) {
+	invalidateAll(l.observers)
+	if !deferNotify() {
+		l.deliver(ops)
+		return
+	}
+	l.pendingOps = append(l.pendingOps, ops...)
+	if l.queued {
+		return
+	}
+	l.queued = true
+	enqueueNotify(l.flush)
+}
+
+// flush delivers the ops recorded since the last flush.
+func (l *List[T]) flush() {
+	ops := l.pendingOps
+	l.pendingOps = nil
+	l.queued = false
+	l.deliver(ops)
+}
+
+// deliver passes ops to OnOp subscribers, then the new slice to OnChange subscribers.
+func (l *List[T]) deliver(ops []ListOp[T]) {
+	for _, op := range ops {
+		for _, cb := range l.onOp {
+			if *cb != nil {
+				(*cb)(op)
+			}
+		}
+	}
 	for _, cb := range l.onChange {
-		cb(l.items)
+		if *cb != nil {
+			(*cb)(l.items)
+		}
 	}
 }
 
-// OnChange adds a callback for any change to the list
-func (l *List[T]) OnChange(cb func([]T)) {
-	l.onChange = append(l.onChange, cb)
+// ItemsAny returns a copy of the items as []any.
+func (l *List[T]) ItemsAny() []any {
+	items := make([]any, len(l.items))
+	for i, item := range l.items {
+		items[i] = item
+	}
+	return items
 }
+
+// OnChangeAny adds a callback that runs on any change without receiving the items.
+func (l *List[T]) OnChangeAny(fn func()) func() { return l.OnChange(func(_ []T) { fn() }) }
@@ -0,0 +1,240 @@
+//go:build !wasm
+
+package preveltekit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListOps(t *testing.T) {
+	l := NewList("a", "b", "c")
+	var ops []ListOp[string]
+	var changes int
+	l.OnOp(func(op ListOp[string]) { ops = append(ops, op) })
+	l.OnChange(func(_ []string) { changes++ })
+
+	tests := []struct {
+		name   string
+		mutate func()
+		want   []string
+		ops    []ListOp[string]
+	}{
+		{"Append", func() { l.Append("d") }, []string{"a", "b", "c", "d"},
+			[]ListOp[string]{{Kind: ListInsert, Index: 3, Items: []string{"d"}}}},
+		{"InsertAt", func() { l.InsertAt(1, "x", "y") }, []string{"a", "x", "y", "b", "c", "d"},
+			[]ListOp[string]{{Kind: ListInsert, Index: 1, Items: []string{"x", "y"}}}},
+		{"RemoveAt", func() { l.RemoveAt(2) }, []string{"a", "x", "b", "c", "d"},
+			[]ListOp[string]{{Kind: ListRemove, Index: 2}}},
+		{"Move forward", func() { l.Move(0, 3) }, []string{"x", "b", "c", "a", "d"},
+			[]ListOp[string]{{Kind: ListMove, Index: 0, To: 3}}},
+		{"Move back", func() { l.Move(4, 0) }, []string{"d", "x", "b", "c", "a"},
+			[]ListOp[string]{{Kind: ListMove, Index: 4, To: 0}}},
+		{"SetAt", func() { l.SetAt(1, "z") }, []string{"d", "z", "b", "c", "a"},
+			[]ListOp[string]{{Kind: ListReplace, Index: 1, Items: []string{"z"}}}},
+		{"Swap", func() { l.Swap(0, 4) }, []string{"a", "z", "b", "c", "d"},
+			[]ListOp[string]{
+				{Kind: ListReplace, Index: 0, Items: []string{"a"}},
+				{Kind: ListReplace, Index: 4, Items: []string{"d"}},
+			}},
+		{"Set", func() { l.Set([]string{"q"}) }, []string{"q"},
+			[]ListOp[string]{{Kind: ListReset, Items: []string{"q"}}}},
+		{"Clear", func() { l.Clear() }, []string{},
+			[]ListOp[string]{{Kind: ListClear}}},
+	}
+
+	for _, tt := range tests {
+		ops = nil
+		changes = 0
+		tt.mutate()
+		if got := l.Get(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: items = %v, want %v", tt.name, got, tt.want)
+		}
+		if !reflect.DeepEqual(ops, tt.ops) {
+			t.Errorf("%s: ops = %+v, want %+v", tt.name, ops, tt.ops)
+		}
+		if changes != 1 {
+			t.Errorf("%s: OnChange fired %d times, want 1", tt.name, changes)
+		}
+	}
+}
+
+func TestComputed(t *testing.T) {
+	first := New("Ada")
+	last := New("Lovelace")
+	useLast := New(true)
+	runs := 0
+	name := Computed(func() string {
+		runs++
+		if useLast.Get() {
+			return first.Get() + " " + last.Get()
+		}
+		return first.Get()
+	})
+
+	// Unsubscribed: recomputes on every Get, holds no subscriptions
+	if got := name.Get(); got != "Ada Lovelace" {
+		t.Fatalf("Get() = %q, want %q", got, "Ada Lovelace")
+	}
+	runs = 0
+	first.Set("Ada")
+	first.Set("Augusta")
+	if runs != 0 {
+		t.Errorf("unsubscribed computed recomputed %d times on a dependency change, want 0", runs)
+	}
+	if got := name.Get(); got != "Augusta Lovelace" {
+		t.Errorf("unsubscribed Get() = %q after a change, want %q", got, "Augusta Lovelace")
+	}
+	first.Set("Ada")
+
+	var seen []string
+	name.OnChange(func(v string) { seen = append(seen, v) })
+	runs = 0
+	name.Get()
+	name.Get()
+	if runs != 0 {
+		t.Errorf("subscribed computed recomputed %d times on Get, want cached", runs)
+	}
+
+	first.Set("Grace")
+	useLast.Set(false)
+	runs = 0
+	last.Set("Hopper") // no longer a dependency
+	want := []string{"Grace Lovelace", "Grace"}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("OnChange values = %v, want %v", seen, want)
+	}
+	if runs != 0 {
+		t.Errorf("dropped dependency still recomputes (%d runs)", runs)
+	}
+
+	items := NewList(1, 2, 3)
+	sum := Computed(func() int {
+		total := 0
+		for _, v := range items.Get() {
+			total += v
+		}
+		return total
+	})
+	doubled := Computed(func() int { return sum.Get() * 2 })
+	var got int
+	doubled.OnChange(func(v int) { got = v })
+	items.Append(4)
+	if got != 20 {
+		t.Errorf("chained computed = %d, want 20", got)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	count := New(0)
+	var first, second int
+	var unsubSecond func()
+	unsubFirst := count.OnChange(func(v int) {
+		first = v
+		unsubSecond() // removed mid-notify: must not fire this round
+	})
+	unsubSecond = count.OnChange(func(v int) { second = v })
+	third := 0
+	count.OnChange(func(v int) { third = v })
+
+	count.Set(1)
+	if first != 1 || second != 0 {
+		t.Errorf("first=%d second=%d, want 1/0", first, second)
+	}
+	unsubFirst()
+	unsubFirst() // idempotent
+	count.Set(2)
+	if first != 1 || second != 0 {
+		t.Errorf("first=%d second=%d after unsubscribe, want 1/0", first, second)
+	}
+	if third != 2 {
+		t.Errorf("remaining callback got %d, want 2", third)
+	}
+
+	items := NewList("x")
+	var ops, changes int
+	unsubOp := items.OnOp(func(_ ListOp[string]) { ops++ })
+	unsubChange := items.OnChange(func(_ []string) { changes++ })
+	items.Append("y")
+	unsubOp()
+	unsubChange()
+	items.Append("z")
+	if ops != 1 || changes != 1 {
+		t.Errorf("list callbacks fired ops=%d changes=%d after unsubscribe, want 1/1", ops, changes)
+	}
+
+	// Dropping the last subscriber of a Computed releases its dependencies
+	src := New(1)
+	runs := 0
+	double := Computed(func() int { runs++; return src.Get() * 2 })
+	unsub := double.OnChange(func(_ int) {})
+	runs = 0
+	src.Set(2)
+	if runs != 1 {
+		t.Fatalf("subscribed computed ran %d times on a change, want 1", runs)
+	}
+	unsub()
+	runs = 0
+	src.Set(5)
+	if runs != 0 {
+		t.Errorf("computed still recomputes (%d runs) after last unsubscribe", runs)
+	}
+	if got := double.Get(); got != 10 {
+		t.Errorf("double.Get() = %d, want 10", got)
+	}
+}
+
+func TestSetSkipsUnchanged(t *testing.T) {
+	name := New("a")
+	calls := 0
+	name.OnChange(func(_ string) { calls++ })
+	name.Set("a")
+	name.Update(func(v string) string { return v })
+	name.Set("b")
+	if calls != 1 {
+		t.Errorf("string store notified %d times, want 1", calls)
+	}
+
+	type status string
+	st := New[status]("idle")
+	calls = 0
+	st.OnChange(func(_ status) { calls++ })
+	st.Set("idle")
+	st.Set("busy")
+	if calls != 1 {
+		t.Errorf("named string store notified %d times, want 1", calls)
+	}
+
+	type user struct{ ID, Visits int }
+	u := NewWithEqual(user{1, 0}, func(a, b user) bool { return a.ID == b.ID })
+	calls = 0
+	u.OnChange(func(_ user) { calls++ })
+	u.Set(user{1, 5})
+	u.Set(user{2, 0})
+	if calls != 1 {
+		t.Errorf("NewWithEqual store notified %d times, want 1", calls)
+	}
+
+	tags := New([]string{"x"}) // not comparable: always notifies
+	calls = 0
+	tags.OnChange(func(_ []string) { calls++ })
+	tags.Set([]string{"x"})
+	if calls != 1 {
+		t.Errorf("slice store notified %d times, want 1", calls)
+	}
+
+	items := NewList("x", "y")
+	lenCalls := 0
+	items.Len().OnChange(func(_ int) { lenCalls++ })
+	calls = 0
+	items.OnChange(func(_ []string) { calls++ })
+	items.Set([]string{"x", "y"})
+	items.SetAt(0, "z")
+	items.SetAt(0, "z")
+	if calls != 1 {
+		t.Errorf("list notified %d times, want 1", calls)
+	}
+	if lenCalls != 0 {
+		t.Errorf("Len() notified %d times without a length change", lenCalls)
+	}
+}
@@ -0,0 +1,359 @@
+// A minimal DOM for running the WASM binding tests under Node.js. It covers
+// the subset of the DOM the runtime uses: node trees, innerHTML, comments,
+// tree walkers, attribute selectors, events with capture and bubbling,
+// classList, inline styles, animations, history and location.
+(function () {
+  const VOID = new Set(["area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "source", "track", "wbr"]);
+
+  class EventTarget_ {
+    constructor() { this._listeners = {}; }
+    addEventListener(type, fn, opts) {
+      const capture = opts === true || !!(opts && opts.capture);
+      (this._listeners[type] = this._listeners[type] || []).push({ fn, capture });
+    }
+    removeEventListener(type, fn, opts) {
+      const capture = opts === true || !!(opts && opts.capture);
+      const list = this._listeners[type] || [];
+      const i = list.findIndex((l) => l.fn === fn && l.capture === capture);
+      if (i >= 0) list.splice(i, 1);
+    }
+    _fire(ev, phase) {
+      for (const l of (this._listeners[ev.type] || []).slice()) {
+        if (phase === 1 && !l.capture) continue;
+        if (phase === 3 && l.capture) continue;
+        ev.currentTarget = this;
+        l.fn.call(this, ev);
+        if (ev._stopImmediate) return;
+      }
+    }
+    dispatchEvent(ev) {
+      ev.target = this;
+      const path = [];
+      for (let n = this.parentNode; n; n = n.parentNode) path.push(n);
+      if (this.isConnected) path.push(window_);
+      for (let i = path.length - 1; i >= 0 && !ev._stop; i--) path[i]._fire(ev, 1);
+      if (!ev._stop) this._fire(ev, 2);
+      if (ev.bubbles) {
+        for (let i = 0; i < path.length && !ev._stop; i++) path[i]._fire(ev, 3);
+      }
+      return !ev.defaultPrevented;
+    }
+  }
+
+  class Event_ {
+    constructor(type, init) {
+      Object.assign(this, { bubbles: true, button: 0, ctrlKey: false, metaKey: false, altKey: false, shiftKey: false }, init);
+      this.type = type;
+      this.defaultPrevented = false;
+    }
+    stopPropagation() { this._stop = true; }
+    stopImmediatePropagation() { this._stop = true; this._stopImmediate = true; }
+    preventDefault() { this.defaultPrevented = true; }
+    get cancelBubble() { return !!this._stop; }
+    set cancelBubble(v) { if (v) this._stop = true; }
+  }
+
+  class Node_ extends EventTarget_ {
+    constructor(nodeType) {
+      super();
+      this.nodeType = nodeType;
+      this.childNodes = [];
+      this.parentNode = null;
+    }
+    get parentElement() { return this.parentNode && this.parentNode.nodeType === 1 ? this.parentNode : null; }
+    get firstChild() { return this.childNodes[0] || null; }
+    get lastChild() { return this.childNodes[this.childNodes.length - 1] || null; }
+    get nextSibling() {
+      if (!this.parentNode) return null;
+      const sib = this.parentNode.childNodes;
+      return sib[sib.indexOf(this) + 1] || null;
+    }
+    get previousSibling() {
+      if (!this.parentNode) return null;
+      const sib = this.parentNode.childNodes;
+      return sib[sib.indexOf(this) - 1] || null;
+    }
+    get isConnected() {
+      let n = this;
+      while (n.parentNode) n = n.parentNode;
+      return n === document_;
+    }
+    insertBefore(node, ref) {
+      if (ref && ref.parentNode !== this) throw new Error("insertBefore: reference is not a child");
+      const nodes = node.nodeType === 11 ? node.childNodes.slice() : [node];
+      for (const n of nodes) {
+        if (n.parentNode) n.parentNode.removeChild(n);
+        n.parentNode = this;
+        const i = ref ? this.childNodes.indexOf(ref) : this.childNodes.length;
+        this.childNodes.splice(i, 0, n);
+      }
+      return node;
+    }
+    appendChild(node) { return this.insertBefore(node, null); }
+    removeChild(node) {
+      const i = this.childNodes.indexOf(node);
+      if (i < 0) throw new Error("removeChild: node is not a child");
+      this.childNodes.splice(i, 1);
+      node.parentNode = null;
+      return node;
+    }
+    remove() { if (this.parentNode) this.parentNode.removeChild(this); }
+    contains(n) {
+      for (; n; n = n.parentNode) if (n === this) return true;
+      return false;
+    }
+    get textContent() {
+      if (this.nodeType === 3) return this.nodeValue;
+      if (this.nodeType === 8) return "";
+      return this.childNodes.map((c) => c.textContent).join("");
+    }
+    set textContent(v) {
+      if (this.nodeType === 3 || this.nodeType === 8) { this.nodeValue = String(v); return; }
+      for (const c of this.childNodes) c.parentNode = null;
+      this.childNodes = [];
+      if (v !== "") this.appendChild(new Text_(String(v)));
+    }
+    _walk(fn) {
+      for (const c of this.childNodes) { fn(c); c._walk(fn); }
+    }
+    querySelectorAll(sel) {
+      const match = parseSelector(sel);
+      const out = [];
+      this._walk((n) => { if (n.nodeType === 1 && match(n)) out.push(n); });
+      return out;
+    }
+    querySelector(sel) { return this.querySelectorAll(sel)[0] || null; }
+    get innerHTML() { return this.childNodes.map(serialize).join(""); }
+    set innerHTML(html) {
+      for (const c of this.childNodes) c.parentNode = null;
+      this.childNodes = [];
+      const target = this.tagName === "TEMPLATE" ? this.content : this;
+      for (const c of target.childNodes) c.parentNode = null;
+      target.childNodes = [];
+      parseHTML(String(html), target);
+    }
+  }
+
+  class Text_ extends Node_ {
+    constructor(v) { super(3); this.nodeValue = v; }
+    get data() { return this.nodeValue; }
+  }
+  class Comment_ extends Node_ {
+    constructor(v) { super(8); this.nodeValue = v; }
+    get data() { return this.nodeValue; }
+  }
+  class Fragment_ extends Node_ {
+    constructor() { super(11); }
+  }
+
+  class ClassList_ {
+    constructor(el) { this.el = el; }
+    _get() { return (this.el.getAttribute("class") || "").split(/\s+/).filter(Boolean); }
+    add(...names) { const c = this._get(); for (const n of names) if (!c.includes(n)) c.push(n); this.el.setAttribute("class", c.join(" ")); }
+    remove(...names) { this.el.setAttribute("class", this._get().filter((c) => !names.includes(c)).join(" ")); }
+    contains(n) { return this._get().includes(n); }
+    toggle(n) { if (this.contains(n)) { this.remove(n); return false; } this.add(n); return true; }
+  }
+
+  class Style_ {
+    constructor() { this._props = {}; }
+    setProperty(k, v) { this._props[k] = String(v); }
+    removeProperty(k) { const v = this._props[k] || ""; delete this._props[k]; return v; }
+    getPropertyValue(k) { return this._props[k] || ""; }
+    get cssText() { return Object.entries(this._props).map(([k, v]) => k + ": " + v + ";").join(" "); }
+  }
+
+  class Element_ extends Node_ {
+    constructor(tag) {
+      super(1);
+      this.tagName = tag.toUpperCase();
+      this.attributes = {};
+      this.classList = new ClassList_(this);
+      this.style = new Style_();
+      if (this.tagName === "TEMPLATE") this.content = new Fragment_();
+    }
+    get localName() { return this.tagName.toLowerCase(); }
+    getAttribute(k) { return k in this.attributes ? this.attributes[k] : null; }
+    setAttribute(k, v) { this.attributes[k] = String(v); }
+    removeAttribute(k) { delete this.attributes[k]; }
+    hasAttribute(k) { return k in this.attributes; }
+    get id() { return this.getAttribute("id") || ""; }
+    set id(v) { this.setAttribute("id", v); }
+    get className() { return this.getAttribute("class") || ""; }
+    set className(v) { this.setAttribute("class", v); }
+    get value() { return this._value !== undefined ? this._value : this.getAttribute("value") || ""; }
+    set value(v) { this._value = String(v); }
+    get checked() { return this._checked !== undefined ? this._checked : this.hasAttribute("checked"); }
+    set checked(v) { this._checked = !!v; }
+    get children() { return this.childNodes.filter((c) => c.nodeType === 1); }
+    click() { this.dispatchEvent(new Event_("click", {})); }
+    focus() { document_.activeElement = this; }
+    blur() { if (document_.activeElement === this) document_.activeElement = document_.body; }
+    scrollIntoView() {}
+    getBoundingClientRect() { return { top: 0, left: 0, right: 0, bottom: 0, width: 0, height: 0, x: 0, y: 0 }; }
+    animate(keyframes, opts) {
+      const anim = {
+        keyframes, options: opts, playState: "running", onfinish: null, oncancel: null,
+        finish() { this.playState = "finished"; if (this.onfinish) this.onfinish({}); },
+        cancel() { this.playState = "idle"; if (this.oncancel) this.oncancel({}); },
+      };
+      (this._animations = this._animations || []).push(anim);
+      return anim;
+    }
+  }
+
+  // Attribute and id selectors, optionally comma-separated:
+  // [attr], [attr="v"], #id, tag
+  function parseSelector(sel) {
+    const parts = sel.split(",").map((s) => s.trim());
+    const tests = parts.map((p) => {
+      let m;
+      if ((m = /^\[([\w-]+)(?:="([^"]*)")?\]$/.exec(p))) {
+        const [, k, v] = m;
+        return (el) => el.hasAttribute(k) && (v === undefined || el.getAttribute(k) === v);
+      }
+      if ((m = /^#([\w-]+)$/.exec(p))) return (el) => el.getAttribute("id") === m[1];
+      if ((m = /^([\w-]+)$/.exec(p))) return (el) => el.localName === m[1].toLowerCase();
+      throw new Error("unsupported selector: " + p);
+    });
+    return (el) => tests.some((t) => t(el));
+  }
+
+  function decode(s) {
+    return s.replace(/&(#\d+|#x[0-9a-f]+|amp|lt|gt|quot|apos|#39);/gi, (_, e) => {
+      switch (e.toLowerCase()) {
+        case "amp": return "&";
+        case "lt": return "<";
+        case "gt": return ">";
+        case "quot": return '"';
+        case "apos": return "'";
+      }
+      return String.fromCodePoint(e[1] === "x" || e[1] === "X" ? parseInt(e.slice(2), 16) : parseInt(e.slice(1), 10));
+    });
+  }
+  function escapeText(s) { return s.replace(/&/g, "&amp;").replace(/</g, "&lt;").replace(/>/g, "&gt;"); }
+  function escapeAttr(s) { return s.replace(/&/g, "&amp;").replace(/"/g, "&quot;"); }
+
+  function serialize(n) {
+    if (n.nodeType === 3) return escapeText(n.nodeValue);
+    if (n.nodeType === 8) return "<!--" + n.nodeValue + "-->";
+    if (n.nodeType === 11) return n.childNodes.map(serialize).join("");
+    let s = "<" + n.localName;
+    for (const [k, v] of Object.entries(n.attributes)) s += v === "" ? " " + k : " " + k + '="' + escapeAttr(v) + '"';
+    s += ">";
+    if (VOID.has(n.localName)) return s;
+    const kids = n.tagName === "TEMPLATE" ? n.content.childNodes : n.childNodes;
+    return s + kids.map(serialize).join("") + "</" + n.localName + ">";
+  }
+
+  function parseHTML(html, root) {
+    const stack = [root];
+    const top = () => stack[stack.length - 1];
+    const into = () => (top().tagName === "TEMPLATE" ? top().content : top());
+    let i = 0;
+    while (i < html.length) {
+      if (html.startsWith("<!--", i)) {
+        const end = html.indexOf("-->", i + 4);
+        into().appendChild(new Comment_(html.slice(i + 4, end)));
+        i = end + 3;
+      } else if (html.startsWith("</", i)) {
+        const end = html.indexOf(">", i);
+        const tag = html.slice(i + 2, end).trim().toUpperCase();
+        for (let j = stack.length - 1; j > 0; j--) {
+          if (stack[j].tagName === tag) { stack.length = j; break; }
+        }
+        i = end + 1;
+      } else if (html[i] === "<" && /[a-zA-Z]/.test(html[i + 1] || "")) {
+        const re = /<([a-zA-Z][\w-]*)|\s*([^\s=>\/]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+)))?|\s*(\/?)>/y;
+        re.lastIndex = i;
+        const m = re.exec(html);
+        const el = new Element_(m[1]);
+        i = re.lastIndex;
+        for (;;) {
+          re.lastIndex = i;
+          const a = re.exec(html);
+          if (!a) throw new Error("bad tag at " + i + ": " + html.slice(i, i + 40));
+          i = re.lastIndex;
+          if (a[6] !== undefined && a[2] === undefined) break;
+          const v = a[3] ?? a[4] ?? a[5] ?? "";
+          el.setAttribute(a[2].toLowerCase(), decode(v));
+        }
+        into().appendChild(el);
+        if (!VOID.has(el.localName)) stack.push(el);
+      } else {
+        let end = html.indexOf("<", i + 1);
+        while (end >= 0 && !/[a-zA-Z\/!]/.test(html[end + 1] || "")) end = html.indexOf("<", end + 1);
+        if (end < 0) end = html.length;
+        into().appendChild(new Text_(decode(html.slice(i, end))));
+        i = end;
+      }
+    }
+  }
+
+  class TreeWalker_ {
+    constructor(root, what) {
+      this.nodes = [];
+      root._walk((n) => {
+        if ((what & 1 && n.nodeType === 1) || (what & 4 && n.nodeType === 3) || (what & 128 && n.nodeType === 8)) this.nodes.push(n);
+      });
+      this.i = 0;
+    }
+    nextNode() { return this.nodes[this.i++] || null; }
+  }
+
+  class Document_ extends Node_ {
+    constructor() {
+      super(9);
+      this.documentElement = new Element_("html");
+      this.appendChild(this.documentElement);
+      this.head = new Element_("head");
+      this.body = new Element_("body");
+      this.documentElement.appendChild(this.head);
+      this.documentElement.appendChild(this.body);
+      this.activeElement = this.body;
+    }
+    createElement(tag) { return new Element_(tag); }
+    createTextNode(v) { return new Text_(String(v)); }
+    createComment(v) { return new Comment_(String(v)); }
+    createDocumentFragment() { return new Fragment_(); }
+    createTreeWalker(root, what) { return new TreeWalker_(root, what); }
+    getElementById(id) { return this.querySelector("#" + id); }
+  }
+
+  const window_ = new EventTarget_();
+  const document_ = new Document_();
+
+  const location_ = { pathname: "/", search: "", hash: "" };
+  function setURL(url) {
+    let rest = url;
+    const h = rest.indexOf("#");
+    location_.hash = h >= 0 && h < rest.length - 1 ? rest.slice(h) : "";
+    if (h >= 0) rest = rest.slice(0, h);
+    if (rest === "") return;
+    const q = rest.indexOf("?");
+    location_.search = q >= 0 && q < rest.length - 1 ? rest.slice(q) : "";
+    if (q >= 0) rest = rest.slice(0, q);
+    if (rest !== "") location_.pathname = rest;
+  }
+  const history_ = {
+    entries: [],
+    pushState(state, title, url) { this.entries.push(url); setURL(url); },
+    replaceState(state, title, url) { this.entries[this.entries.length - 1] = url; setURL(url); },
+  };
+
+  Object.assign(globalThis, {
+    document: document_,
+    window: globalThis,
+    location: location_,
+    history: history_,
+    Event: Event_,
+    addEventListener: window_.addEventListener.bind(window_),
+    removeEventListener: window_.removeEventListener.bind(window_),
+    dispatchEvent: (ev) => { ev.target = window_; window_._fire(ev, 2); },
+    requestAnimationFrame: (fn) => setTimeout(() => fn(Date.now()), 0),
+    getComputedStyle: () => ({ transitionDuration: "0s", transitionDelay: "0s", animationDuration: "0s", animationDelay: "0s" }),
+    scrollTo: () => {},
+    // setLocation moves the shim to a URL without a history entry, for tests
+    __setLocation: (url) => { location_.pathname = "/"; location_.search = ""; location_.hash = ""; setURL(url); },
+  });
+})();
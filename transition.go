@@ -0,0 +1,92 @@
+package preveltekit
+
+// Transition animates content entering and leaving an If block, the items of
+// an Each block, or the component of a Store[Component] (see
+// WithTransition). Transitions run in WASM only; SSR renders the
+// settled state.
+//
+// Each direction is driven by the first of these that is set:
+//
+//  1. Enter / Leave: JS-driven, call done when finished
+//  2. Class: CSS classes, Vue-style. Entering elements get
+//     <Class>-enter-from and <Class>-enter-active, then -enter-from is
+//     swapped for -enter-to on the next frame; leaving elements get the
+//     same with -leave-*. The transition ends after Duration, or after the
+//     element's computed CSS transition/animation time if Duration is 0.
+//  3. a preset's keyframes (Fade, Slide, Scale), played with the Web
+//     Animations API, reversed for leave
+//
+// Outgoing nodes stay in the DOM until their animation ends. By default old
+// and new content animate at the same time (a cross-fade); set OutIn to
+// reveal the new content only after the old one has left. Only element
+// nodes animate; top-level text nodes are swapped instantly.
+//
+// Example:
+//
+//	p.If(p.Cond(open.Get, open), panel).Transition(p.Slide(250))
+//	p.EachKeyed(todos, key, row).Transition(p.Fade(150))
+//	p.WithTransition(current, p.Fade(200)) // cross-fading routes
+//	p.If(cond, body).Transition(p.Transition{Class: "pop", Duration: 300})
+type Transition struct {
+	Duration int    // milliseconds; 0 = use the CSS duration (Class transitions only)
+	Easing   string // CSS easing function, default "ease"
+	Class    string // CSS class prefix for class-based transitions
+	OutIn    bool   // wait for the old content to leave before the new content enters
+
+	Enter func(el Element, done func()) // JS-driven enter animation
+	Leave func(el Element, done func()) // JS-driven leave animation
+
+	keyframes func(el Element) []any // preset animation, played forward on enter
+}
+
+// Fade fades content in and out over ms milliseconds.
+func Fade(ms int) Transition {
+	return Transition{Duration: ms, keyframes: func(Element) []any {
+		return []any{
+			map[string]any{"opacity": 0},
+			map[string]any{"opacity": 1},
+		}
+	}}
+}
+
+// Slide collapses and expands the content's height over ms milliseconds.
+func Slide(ms int) Transition {
+	return Transition{Duration: ms, keyframes: func(el Element) []any {
+		height := ftoa(el.GetBoundingRect().Height) + "px"
+		return []any{
+			map[string]any{"height": "0px", "opacity": 0, "overflow": "hidden"},
+			map[string]any{"height": height, "opacity": 1, "overflow": "hidden"},
+		}
+	}}
+}
+
+// Scale grows content from 80% size while fading it in, and the reverse on leave.
+func Scale(ms int) Transition {
+	return Transition{Duration: ms, keyframes: func(Element) []any {
+		return []any{
+			map[string]any{"opacity": 0, "transform": "scale(0.8)"},
+			map[string]any{"opacity": 1, "transform": "scale(1)"},
+		}
+	}}
+}
+
+// Transition animates branch changes of the if-block.
+func (i *IfNode) Transition(t Transition) *IfNode {
+	i.Trans = &t
+	return i
+}
+
+// Transition animates items entering and leaving the list, and the Else
+// content. EachKeyed blocks animate each added or removed item; a plain
+// Each re-renders all items on every change, so the whole list leaves and
+// the new one enters.
+func (e *EachNode) Transition(t Transition) *EachNode {
+	e.Trans = &t
+	return e
+}
+
+// WithTransition animates the component swaps of s, e.g. a cross-fade
+// between routes.
+func WithTransition(s *Store[Component], t Transition) {
+	s.transition = &t
+}
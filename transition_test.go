@@ -0,0 +1,41 @@
+//go:build !wasm
+
+package preveltekit
+
+import "testing"
+
+func TestTransition(t *testing.T) {
+	for name, tr := range map[string]Transition{"fade": Fade(200), "slide": Slide(200), "scale": Scale(200)} {
+		if tr.Duration != 200 {
+			t.Errorf("%s: Duration = %d", name, tr.Duration)
+		}
+		if frames := tr.keyframes(Element{}); len(frames) != 2 {
+			t.Errorf("%s: %d keyframes, want 2", name, len(frames))
+		}
+	}
+
+	// Transitions only animate in the browser: SSR output is unchanged
+	show := New(true)
+	items := NewList("a", "b")
+	plain := Div(
+		If(Cond(show.Get, show), P("on")).Else(P("off")),
+		EachKeyed(items, func(s string) string { return s }, func(s string, _ int) Node { return Li(s) }),
+		Each(items, func(s string, _ int) Node { return Li(s) }),
+	)
+	animated := Div(
+		If(Cond(show.Get, show), P("on")).Else(P("off")).Transition(Fade(150)),
+		EachKeyed(items, func(s string) string { return s }, func(s string, _ int) Node { return Li(s) }).
+			Transition(Transition{Class: "pop"}),
+		Each(items, func(s string, _ int) Node { return Li(s) }).Transition(Scale(100)),
+	)
+	want := nodeToHTML(plain, NewBuildContext())
+	if got := nodeToHTML(animated, NewBuildContext()); got != want {
+		t.Errorf("transition changed SSR output:\n got %s\nwant %s", got, want)
+	}
+
+	comp := New[Component](nil)
+	WithTransition(comp, Fade(100))
+	if comp.transition == nil || comp.transition.Duration != 100 {
+		t.Error("WithTransition not stored")
+	}
+}
@@ -0,0 +1,315 @@
+//go:build wasm
+
+package preveltekit
+
+import "syscall/js"
+
+// leavingProp marks DOM nodes that are animating out. They stay between the
+// block markers until their leave transition ends, but no longer belong to
+// the block's content.
+const leavingProp = "__pkLeaving"
+
+// swapMarkerContent replaces the content between <!--{markerID}s--> and
+// <!--{markerID}--> like replaceMarkerContent, animating the change with t.
+// The new nodes are inserted immediately, so bindings can be wired right
+// away; the old nodes are neutralised (see retire) and removed once their
+// leave transition has finished.
+func swapMarkerContent(markerID, html string, t *Transition) {
+	if t == nil {
+		replaceMarkerContent(markerID, html)
+		return
+	}
+	endMarker := findComment(markerID)
+	startMarker := findComment(markerID + "s")
+	if endMarker.IsNull() || startMarker.IsNull() {
+		return
+	}
+	parent := endMarker.Get("parentNode")
+
+	var old []js.Value
+	for n := startMarker.Get("nextSibling"); !n.IsNull() && !n.Equal(endMarker); n = n.Get("nextSibling") {
+		if !n.Get(leavingProp).Truthy() {
+			old = append(old, n)
+		}
+	}
+
+	nodes := htmlToNodes(html)
+	for _, n := range nodes {
+		parent.Call("insertBefore", n, endMarker)
+	}
+
+	if !t.OutIn {
+		leaveNodes(old, t, nil)
+		enterNodes(nodes, t)
+		return
+	}
+
+	// Out-in: keep the new content hidden until the old content has left
+	display := make([]string, len(nodes))
+	for i, n := range nodes {
+		if isElement(n) {
+			style := n.Get("style")
+			display[i] = style.Call("getPropertyValue", "display").String()
+			style.Call("setProperty", "display", "none")
+		}
+	}
+	leaveNodes(old, t, func() {
+		var shown []js.Value
+		for i, n := range nodes {
+			if !isElement(n) || n.Get(leavingProp).Truthy() {
+				continue // swapped out again while hidden
+			}
+			shown = append(shown, n)
+			if display[i] == "" {
+				n.Get("style").Call("removeProperty", "display")
+			} else {
+				n.Get("style").Call("setProperty", "display", display[i])
+			}
+		}
+		enterNodes(shown, t)
+	})
+}
+
+// enterNodes runs the enter transition on every element in nodes.
+func enterNodes(nodes []js.Value, t *Transition) {
+	for _, n := range nodes {
+		if isElement(n) {
+			runTransition(n, t, true, nil)
+		}
+	}
+}
+
+// leaveNodes retires nodes, runs the leave transition on the elements and
+// removes each node when its transition ends. done (may be nil) runs once
+// all nodes are gone.
+func leaveNodes(nodes []js.Value, t *Transition, done func()) {
+	pending := 1 // released after the loop, so done runs at most once
+	release := func() {
+		pending--
+		if pending == 0 && done != nil {
+			done()
+		}
+	}
+	for _, n := range nodes {
+		retire(n)
+		if !isElement(n) {
+			removeNode(n)
+			continue
+		}
+		pending++
+		n.Set(leavingProp, true)
+		runTransition(n, t, false, func() {
+			removeNode(n)
+			release()
+		})
+	}
+	release()
+}
+
+// retire strips everything hydration looks up by name from an outgoing node:
+// element ids, data-attrbind attributes and comment markers. The incoming
+// content often reuses the same IDs, and getElementById/findComment must
+// find the new nodes, not the ones still animating out. The node is also
+// made inert so it cannot be clicked or focused while it leaves.
+func retire(n js.Value) {
+	if !isElement(n) {
+		if n.Get("nodeType").Int() == 8 {
+			n.Set("nodeValue", "")
+		}
+		return
+	}
+	n.Call("setAttribute", "inert", "")
+	n.Call("removeAttribute", "id")
+	n.Call("removeAttribute", "data-attrbind")
+	tagged := n.Call("querySelectorAll", "[id],[data-attrbind]")
+	for i := 0; i < tagged.Length(); i++ {
+		el := tagged.Index(i)
+		el.Call("removeAttribute", "id")
+		el.Call("removeAttribute", "data-attrbind")
+	}
+	walker := document.Call("createTreeWalker", n, nodeFilterShowComment, js.Null())
+	for c := walker.Call("nextNode"); !c.IsNull(); c = walker.Call("nextNode") {
+		c.Set("nodeValue", "")
+	}
+}
+
+// skipLeaving returns the first node from n on that is not animating out.
+func skipLeaving(n js.Value) js.Value {
+	for ok(n) && n.Get(leavingProp).Truthy() {
+		n = n.Get("nextSibling")
+	}
+	return n
+}
+
+// runTransition plays one direction of t on el and calls done (may be nil)
+// when it has finished.
+func runTransition(el js.Value, t *Transition, enter bool, done func()) {
+	finished := false
+	finish := func() {
+		if finished {
+			return
+		}
+		finished = true
+		if done != nil {
+			done()
+		}
+	}
+	h := Element{ID: jsString(el.Get("id")), ref: elementRef{el}}
+	switch {
+	case enter && t.Enter != nil:
+		t.Enter(h, finish)
+	case !enter && t.Leave != nil:
+		t.Leave(h, finish)
+	case t.Class != "":
+		classTransition(el, t, enter, finish)
+	case t.keyframes != nil:
+		keyframeTransition(el, h, t, enter, finish)
+	default:
+		finish()
+	}
+}
+
+// classTransition applies the <Class>-enter-*/<Class>-leave-* classes and
+// finishes after Duration, or the element's computed CSS duration.
+func classTransition(el js.Value, t *Transition, enter bool, finish func()) {
+	phase := "-leave"
+	if enter {
+		phase = "-enter"
+	}
+	from := t.Class + phase + "-from"
+	active := t.Class + phase + "-active"
+	to := t.Class + phase + "-to"
+
+	classes := el.Get("classList")
+	classes.Call("add", from, active)
+	afterNextFrame(func() {
+		classes.Call("remove", from)
+		classes.Call("add", to)
+		ms := t.Duration
+		if ms <= 0 {
+			ms = cssDuration(el)
+		}
+		SetTimeout(ms, func() {
+			if enter {
+				classes.Call("remove", active, to)
+			}
+			// leaving elements keep their final classes until removed
+			finish()
+		})
+	})
+}
+
+// keyframeTransition plays a preset with the Web Animations API, reversed
+// for leave. Leaving elements keep the final frame until they are removed.
+func keyframeTransition(el js.Value, h Element, t *Transition, enter bool, finish func()) {
+	if el.Get("animate").Type() != js.TypeFunction {
+		finish()
+		return
+	}
+	easing := t.Easing
+	if easing == "" {
+		easing = "ease"
+	}
+	opts := map[string]any{"duration": t.Duration, "easing": easing}
+	if !enter {
+		opts["direction"] = "reverse"
+		opts["fill"] = "forwards"
+	}
+	anim := el.Call("animate", js.ValueOf(t.keyframes(h)), js.ValueOf(opts))
+	// One func serves both handlers; detach it from the animation before
+	// releasing it, so a cancel() after finish (fill: forwards) calls nothing
+	var onEnd js.Func
+	onEnd = js.FuncOf(func(this js.Value, args []js.Value) any {
+		anim.Set("onfinish", js.Null())
+		anim.Set("oncancel", js.Null())
+		onEnd.Release()
+		finish()
+		return nil
+	})
+	anim.Set("onfinish", onEnd)
+	anim.Set("oncancel", onEnd)
+}
+
+// cssDuration returns the longest transition or animation (duration + delay)
+// of el in milliseconds, from its computed style.
+func cssDuration(el js.Value) int {
+	style := js.Global().Call("getComputedStyle", el)
+	longest := 0.0
+	for _, prop := range [][2]string{
+		{"transitionDuration", "transitionDelay"},
+		{"animationDuration", "animationDelay"},
+	} {
+		durations := splitCSSList(style.Get(prop[0]).String())
+		delays := splitCSSList(style.Get(prop[1]).String())
+		for i, d := range durations {
+			ms := cssTimeMs(d)
+			if len(delays) > 0 {
+				ms += cssTimeMs(delays[i%len(delays)])
+			}
+			if ms > longest {
+				longest = ms
+			}
+		}
+	}
+	return int(longest)
+}
+
+// splitCSSList splits a computed comma-separated list like "0.3s, 0s".
+func splitCSSList(s string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || s[i] == ',' {
+			part := s[start:i]
+			for len(part) > 0 && part[0] == ' ' {
+				part = part[1:]
+			}
+			if part != "" {
+				parts = append(parts, part)
+			}
+			start = i + 1
+		}
+	}
+	return parts
+}
+
+// cssTimeMs converts a CSS time ("0.3s", "300ms") to milliseconds.
+func cssTimeMs(s string) float64 {
+	f := js.Global().Call("parseFloat", s).Float()
+	if f != f { // NaN
+		return 0
+	}
+	if len(s) >= 2 && s[len(s)-2:] == "ms" {
+		return f
+	}
+	return f * 1000
+}
+
+// afterNextFrame runs fn after the next frame has been painted, so styles
+// set before it (e.g. -enter-from classes) take effect first.
+func afterNextFrame(fn func()) {
+	var outer, inner js.Func
+	inner = js.FuncOf(func(this js.Value, args []js.Value) any {
+		inner.Release()
+		fn()
+		return nil
+	})
+	outer = js.FuncOf(func(this js.Value, args []js.Value) any {
+		outer.Release()
+		js.Global().Call("requestAnimationFrame", inner)
+		return nil
+	})
+	js.Global().Call("requestAnimationFrame", outer)
+}
+
+// isElement reports whether n is an element node.
+func isElement(n js.Value) bool {
+	return ok(n) && n.Get("nodeType").Int() == 1
+}
+
+// removeNode detaches n from its parent, if it still has one.
+func removeNode(n js.Value) {
+	if parent := n.Get("parentNode"); ok(parent) {
+		parent.Call("removeChild", n)
+	}
+}
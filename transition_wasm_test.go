@@ -0,0 +1,76 @@
+//go:build wasm
+
+package preveltekit
+
+import "testing"
+
+func TestTransitionLeaveKeyframes(t *testing.T) {
+	resetRegistries()
+	show := New(true)
+	count := New(0)
+	body := mount(t, Div(
+		If(Cond(show.Get, show), P(Attr("id", "panel"), "on", Bind(count))).Else(P("off")).Transition(Fade(100)),
+	))
+	old := document.Call("getElementById", "panel")
+
+	show.Set(false)
+	if got := text(body); got != "on0off" {
+		t.Fatalf("while leaving: text = %q, want on0off", got)
+	}
+	// The leaving node is retired: no id, no markers, inert
+	if !document.Call("getElementById", "panel").IsNull() {
+		t.Error("leaving node still has its id")
+	}
+	if old.Call("getAttribute", "inert").IsNull() {
+		t.Error("leaving node is not inert")
+	}
+	if got := old.Get("innerHTML").String(); got != "on<!---->0<!---->" {
+		t.Errorf("leaving node still has its markers: %s", got)
+	}
+
+	anim := old.Get("_animations").Index(0)
+	if anim.Get("options").Get("direction").String() != "reverse" {
+		t.Error("leave animation does not run in reverse")
+	}
+	anim.Call("finish")
+	if !old.Get("parentNode").IsNull() {
+		t.Error("leaving node not removed after its animation finished")
+	}
+	if got := text(body); got != "off" {
+		t.Errorf("after leave: text = %q, want off", got)
+	}
+	if !anim.Get("onfinish").IsNull() || !anim.Get("oncancel").IsNull() {
+		t.Error("animation handlers not detached after finish")
+	}
+	anim.Call("cancel") // must not call the released handler
+}
+
+func TestTransitionLeaveClass(t *testing.T) {
+	resetRegistries()
+	items := NewList("a", "b", "c")
+	body := mount(t, Ul(EachKeyed(items, func(s string) string { return s }, func(s string, _ int) Node {
+		return Li(Attr("id", "item-"+s), s)
+	}).Transition(Transition{Class: "pop", Duration: 1})))
+	old := document.Call("getElementById", "item-b")
+
+	items.RemoveAt(1)
+	if !old.Get("classList").Call("contains", "pop-leave-active").Bool() {
+		t.Error("leaving item has no leave classes")
+	}
+	if old.Get("id").String() != "" {
+		t.Error("leaving item still has its id")
+	}
+	waitFor(t, "the leaving item to be removed", func() bool { return old.Get("parentNode").IsNull() })
+	if got := text(body); got != "ac" {
+		t.Errorf("after leave: text = %q, want ac", got)
+	}
+
+	// A re-added key gets fresh DOM
+	items.InsertAt(1, "b")
+	if el := document.Call("getElementById", "item-b"); el.IsNull() || el.Equal(old) {
+		t.Error("re-added item did not get new DOM")
+	}
+	if got := text(body); got != "abc" {
+		t.Errorf("after re-adding: text = %q, want abc", got)
+	}
+}